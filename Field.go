@@ -26,21 +26,29 @@ type Field struct {
 	Disabled     bool
 	TableOptions TableOptions
 	// BlockEditorOptions BlockEditorOptions
-	Placeholder  string
-	Invisible    bool
-	CustomInput  hb.TagInterface
-	Attrs        map[string]string
-	Multiple     bool
-	Validators   []Validator
-	theme        *Theme
-	errorMessage string
+	Placeholder       string
+	Invisible         bool
+	CustomInput       hb.TagInterface
+	Attrs             map[string]string
+	Multiple          bool
+	Values            []string // selected values of a multiple select
+	Validators        []Rule
+	ContextValidators []ContextValidator // run by Form.ValidateContext, e.g. database lookups
+	Sanitizers        []Sanitizer        // clean up submitted values before validation
+	Signed            bool               // value is rendered with a signature and verified when parsed, see Form.WithKeyring
+	theme             *Theme
+	errorMessages     []string
+	keyring           Keyring         // signs the value of a signed field, set by Form.Build
+	invalidSignatures map[string]bool // input names whose last parsed value had no valid signature
+	optionsFunc       string          // name of the registered options function, see WithOptionsFunc
 }
 
 var _ themeable = (*Field)(nil)
 var _ errorAware = (*Field)(nil)
+var _ keyringAware = (*Field)(nil)
 
-func (field *Field) setError(message string) {
-	field.errorMessage = message
+func (field *Field) setErrors(messages []string) {
+	field.errorMessages = messages
 }
 
 func (field *Field) setTheme(theme *Theme) {
@@ -107,6 +115,7 @@ func (field *Field) GetOptionsF() func() []FieldOption {
 
 func (field *Field) SetOptionsF(fieldOptionsF func() []FieldOption) {
 	field.OptionsF = fieldOptionsF
+	field.optionsFunc = ""
 }
 
 func (field *Field) GetRequired() bool {
@@ -258,7 +267,7 @@ func (field *Field) fieldInput(fileManagerURL string) *hb.Tag {
 			ID(field.ID).
 			Class(field.getTheme().InputClass).
 			Name(field.Name).
-			Value(field.Value)
+			Value(field.renderedValue())
 
 		if field.Placeholder != "" {
 			input.Placeholder(field.Placeholder)
@@ -327,6 +336,12 @@ func (field *Field) fieldInput(fileManagerURL string) *hb.Tag {
 		input.Style("background: #efefef;")
 	}
 
+	if field.supportsConstraintAttrs() {
+		for k, v := range field.constraintAttrs() {
+			input.Attr(k, v)
+		}
+	}
+
 	for k, v := range field.Attrs {
 		input.Attr(k, v)
 	}
@@ -428,20 +443,29 @@ func (field *Field) fieldSelect() *hb.Tag {
 
 	for _, opt := range field.Options {
 		option := hb.NewOption().Value(opt.Key).HTML(opt.Value)
-		option.AttrIf(field.Value == opt.Key, "selected", "selected")
+		option.AttrIf(field.isSelected(opt.Key), "selected", "selected")
 		input.AddChild(option)
 	}
 
 	if field.OptionsF != nil {
 		for _, opt := range field.OptionsF() {
 			option := hb.NewOption().Value(opt.Key).HTML(opt.Value)
-			option.AttrIf(field.Value == opt.Key, "selected", "selected")
+			option.AttrIf(field.isSelected(opt.Key), "selected", "selected")
 			input.AddChild(option)
 		}
 	}
 	return input
 }
 
+// isSelected reports whether the option key is the field's value or,
+// for multiple selects, one of the field's values.
+func (field *Field) isSelected(key string) bool {
+	if field.Value == key {
+		return true
+	}
+	return field.Multiple && lo.Contains(field.Values, key)
+}
+
 func (field *Field) fieldTable(fileManagerURL string) *hb.Tag {
 	header := hb.NewThead()
 	if field.TableOptions.RowDeleteButton != nil {
@@ -497,18 +521,29 @@ func (field *Field) fieldCheckbox() *hb.Tag {
 		Name(field.Name).
 		Value(lo.If(field.Value != "", field.Value).Else("1"))
 
-	if field.Value == "1" || field.Value == "true" || field.Value == "on" || field.Value == "yes" {
+	if isTruthy(field.Value) {
 		input.Attr("checked", "checked")
 	}
 
+	if required, ok := field.constraintAttrs()["required"]; ok {
+		input.Attr("required", required)
+	}
+
 	wrapper.Child(input)
 
 	return wrapper
 }
 
+// isTruthy reports whether a value marks a checkbox as checked.
+func isTruthy(value string) bool {
+	return value == "1" || value == "true" || value == "on" || value == "yes"
+}
+
 func (field *Field) fieldRadio() *hb.Tag {
 	wrapper := hb.NewDiv()
 
+	required, isRequired := field.constraintAttrs()["required"]
+
 	for _, opt := range field.Options {
 		radioDiv := hb.NewDiv().Class(field.getTheme().RadioWrapClass)
 
@@ -522,6 +557,10 @@ func (field *Field) fieldRadio() *hb.Tag {
 			radioInput.Attr("checked", "checked")
 		}
 
+		if isRequired {
+			radioInput.Attr("required", required)
+		}
+
 		radioLabel := hb.NewLabel().
 			Class(field.getTheme().RadioLabelClass).
 			HTML(opt.Value)
@@ -591,8 +630,8 @@ func (field *Field) BuildFormGroup(fileManagerURL string) *hb.Tag {
 
 	fieldInput := field.fieldInput(fileManagerURL)
 
-	// Add error class to input and render error message
-	if field.errorMessage != "" {
+	// Add error class to input and render error messages
+	if len(field.errorMessages) > 0 {
 		theme := field.getTheme()
 		if theme.ErrorInputClass != "" {
 			fieldInput.Class(theme.ErrorInputClass)
@@ -602,11 +641,10 @@ func (field *Field) BuildFormGroup(fileManagerURL string) *hb.Tag {
 	formGroup.Child(fieldInput)
 	formGroup.Child(hiddenInput)
 
-	if field.errorMessage != "" {
+	if len(field.errorMessages) > 0 {
 		theme := field.getTheme()
 		if theme.ErrorClass != "" {
-			errorDiv := hb.NewDiv().Class(theme.ErrorClass).HTML(field.errorMessage)
-			formGroup.Child(errorDiv)
+			formGroup.Child(buildErrorMessages(theme, field.errorMessages))
 		}
 	}
 
@@ -18,10 +18,30 @@ type Form struct {
 	hxTarget string
 	hxSwap   string
 
-	theme  *Theme
-	errors map[string]string // field name -> error message
+	theme        *Theme
+	errors       map[string][]string // field name -> error messages
+	formErrors   []string            // errors that belong to no single field
+	errorSummary bool                // render an error summary at the top of the form
+	noValidate   bool                // render novalidate, turning off the browser's constraint checks
+
+	locale   string          // locale of validation messages, e.g. "de"
+	messages MessageProvider // validation message templates, defaults to DefaultMessages()
 
 	htmxConfig *HTMXConfig
+
+	validationConcurrency int // max context validators run at once by ValidateContext
+
+	csrf      CSRFProvider // issues and verifies anti-CSRF tokens, if set
+	csrfToken string       // token rendered by the last Build
+
+	spamProtection *SpamProtectionOptions // honeypot and render timestamp, if set
+
+	keyring Keyring // signs the values of signed fields and the submission tokens
+
+	idempotency     IdempotencyStore // remembers used submission tokens, if set
+	submissionToken string           // token claimed by the last ParseRequest
+
+	strictParsing string // StrictDrop or StrictReject, if set
 }
 
 // AddField appends a field to the form.
@@ -51,15 +71,68 @@ func (form *Form) SetFileManagerURL(url string) {
 
 // SetErrors sets the validation error messages to display inline next to fields.
 // The map keys are field names, values are error messages.
+// Use SetFieldErrors to set several messages per field.
 func (form *Form) SetErrors(errors map[string]string) {
-	form.errors = errors
+	form.errors = singleErrors(errors)
 }
 
-// GetErrors returns the current validation error messages.
+// GetErrors returns the first validation error message of each field.
+// Use GetFieldErrors to get all messages.
 func (form *Form) GetErrors() map[string]string {
+	if form.errors == nil {
+		return nil
+	}
+	errors := make(map[string]string, len(form.errors))
+	for name, messages := range form.errors {
+		if len(messages) > 0 {
+			errors[name] = messages[0]
+		}
+	}
+	return errors
+}
+
+// SetFieldErrors sets the validation error messages to display inline next to
+// fields. The map keys are field names, values are all the field's messages.
+func (form *Form) SetFieldErrors(errors map[string][]string) {
+	form.errors = errors
+}
+
+// GetFieldErrors returns all current validation error messages of each field.
+func (form *Form) GetFieldErrors() map[string][]string {
 	return form.errors
 }
 
+// AddFormError adds an error that belongs to the form as a whole rather than
+// to a single field, e.g. "The email or password is incorrect".
+// Form errors are shown in the error summary at the top of the form.
+func (form *Form) AddFormError(message string) {
+	form.formErrors = append(form.formErrors, message)
+}
+
+// GetFormErrors returns the errors added with AddFormError.
+func (form *Form) GetFormErrors() []string {
+	return form.formErrors
+}
+
+// isHTMX reports whether the form is submitted by HTMX.
+func (form *Form) isHTMX() bool {
+	if form.hxPost != "" {
+		return true
+	}
+	return form.htmxConfig != nil && (form.htmxConfig.Post != "" || form.htmxConfig.Get != "")
+}
+
+// findField returns the field with the given name, looking inside field rows,
+// or nil if the form has no such field.
+func (form *Form) findField(name string) FieldInterface {
+	for _, field := range flattenFields(form.fields) {
+		if field.GetName() == name {
+			return field
+		}
+	}
+	return nil
+}
+
 // formAware is an optional interface for fields that need a reference to their parent form.
 type formAware interface {
 	setForm(form *Form)
@@ -72,12 +145,36 @@ type themeable interface {
 
 // errorAware is an optional interface for fields that support inline error display.
 type errorAware interface {
-	setError(message string)
+	setErrors(messages []string)
+}
+
+// keyringAware is an optional interface for fields that sign their values.
+type keyringAware interface {
+	setKeyring(keyring Keyring)
 }
 
 // rowErrorAware is an optional interface for layout fields that distribute errors to children.
 type rowErrorAware interface {
-	setErrors(errors map[string]string)
+	setFieldErrors(errors map[string][]string)
+}
+
+// fieldContainer is an optional interface for layout fields that wrap other fields.
+type fieldContainer interface {
+	childFields() []FieldInterface
+}
+
+// flattenFields expands layout containers (such as field rows) into the
+// fields they hold, recursively, preserving the rendering order.
+func flattenFields(fields []FieldInterface) []FieldInterface {
+	flat := []FieldInterface{}
+	for _, field := range fields {
+		if fc, ok := field.(fieldContainer); ok {
+			flat = append(flat, flattenFields(fc.childFields())...)
+			continue
+		}
+		flat = append(flat, field)
+	}
+	return flat
 }
 
 // Build renders the form and all its fields into an hb.Tag HTML element.
@@ -89,24 +186,52 @@ func (form *Form) Build() *hb.Tag {
 		theme = defaultTheme
 	}
 
-	for _, field := range form.fields {
+	if form.csrf != nil {
+		form.csrfToken = form.csrf.Token()
+	}
+
+	// Fields in rows need the form too, e.g. repeaters for its ID and CSRF token
+	for _, field := range flattenFields(form.fields) {
 		if fa, ok := field.(formAware); ok {
 			fa.setForm(form)
 		}
+		if ka, ok := field.(keyringAware); ok {
+			ka.setKeyring(form.keyring)
+		}
+	}
+
+	for _, field := range form.fields {
 		if th, ok := field.(themeable); ok {
 			th.setTheme(theme)
 		}
 		if ea, ok := field.(errorAware); ok && form.errors != nil {
-			if msg, exists := form.errors[field.GetName()]; exists {
-				ea.setError(msg)
+			if messages, exists := form.errors[field.GetName()]; exists {
+				ea.setErrors(messages)
 			}
 		}
 		if rea, ok := field.(rowErrorAware); ok && form.errors != nil {
-			rea.setErrors(form.errors)
+			rea.setFieldErrors(form.errors)
 		}
 		tags = append(tags, field.BuildFormGroup(form.fileManagerURL))
 	}
 
+	// The summary is built after the fields, once their input IDs are assigned
+	if summary := form.buildErrorSummary(theme); summary != nil {
+		tags = append([]hb.TagInterface{summary}, tags...)
+	}
+
+	if form.spamProtection != nil {
+		tags = append(form.spamInputs(theme), tags...)
+	}
+
+	if form.idempotency != nil {
+		tags = append([]hb.TagInterface{form.idempotencyInput()}, tags...)
+	}
+
+	if form.csrf != nil {
+		tags = append([]hb.TagInterface{form.csrfInput()}, tags...)
+	}
+
 	hbForm := hb.Form()
 	hbForm.Children(tags)
 	hbForm.Method(form.method)
@@ -123,6 +248,10 @@ func (form *Form) Build() *hb.Tag {
 		hbForm.Class(form.className)
 	}
 
+	if form.noValidate {
+		hbForm.Attr("novalidate", "novalidate")
+	}
+
 	if form.hxPost != "" {
 		hbForm.HxPost(form.hxPost)
 	}
@@ -175,5 +304,9 @@ func (form *Form) Build() *hb.Tag {
 		}
 	}
 
+	if form.isHTMX() {
+		hbForm.Hx("headers", form.csrfHeaders())
+	}
+
 	return hbForm
 }
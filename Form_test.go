@@ -160,8 +160,8 @@ func TestFormWithRepeater(t *testing.T) {
 		`hx-post="REPEATER_REMOVE_URL`,
 		`hx-post="REPEATER_MOVE_UP_URL`,
 		`hx-post="REPEATER_MOVE_DOWN_URL`,
-		`name="REPEATER_NAME[NAME_1][]"`,
-		`name="REPEATER_NAME[NAME_2][]"`,
+		`name="REPEATER_NAME[NAME_1][0]"`,
+		`name="REPEATER_NAME[NAME_2][0]"`,
 		`value="VALUE_1_01"`,
 		`value="VALUE_1_02"`,
 		`value="VALUE_2_01"`,
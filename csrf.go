@@ -0,0 +1,163 @@
+package form
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dracory/hb"
+)
+
+// Names under which the anti-CSRF token is rendered and read back.
+const (
+	CSRFFieldName  = "csrf_token"   // hidden input rendered by Build
+	CSRFHeaderName = "X-CSRF-Token" // header sent by HTMX requests through hx-headers
+)
+
+// ErrInvalidCSRFToken is returned by ParseRequest when a form with a
+// CSRFProvider receives a request with a missing or invalid token.
+var ErrInvalidCSRFToken = errors.New("form: missing or invalid CSRF token")
+
+// CSRFProvider issues and verifies the anti-CSRF tokens of a form.
+type CSRFProvider interface {
+	// Token returns a new token, rendered each time the form is built.
+	Token() string
+
+	// Verify reports whether a submitted token is valid.
+	Verify(token string) bool
+}
+
+// HMACCSRFOptions configures a new HMAC CSRF provider.
+type HMACCSRFOptions struct {
+	// Key signs the tokens. It must be secret, at least 32 random bytes,
+	// and shared by all servers that verify the tokens.
+	Key []byte
+
+	// SessionID binds the tokens to the user's session, so a token issued
+	// to one user is rejected for another. Without it, any token issued by
+	// the site is accepted.
+	SessionID string
+
+	// MaxAge is how long a token is valid, 12 hours by default.
+	MaxAge time.Duration
+
+	// Now returns the current time, time.Now by default.
+	Now func() time.Time
+}
+
+// hmacCSRFProvider is a stateless CSRFProvider: its tokens carry their
+// issue time and a random nonce, signed with HMAC-SHA256.
+type hmacCSRFProvider struct {
+	key       []byte
+	sessionID string
+	maxAge    time.Duration
+	now       func() time.Time
+}
+
+var _ CSRFProvider = (*hmacCSRFProvider)(nil)
+
+// NewHMACCSRFProvider creates a CSRFProvider that needs no store: tokens are
+// signed with the key and verified by checking their signature and age.
+// Create it per request, with the session of the request's user:
+//
+//	provider, err := form.NewHMACCSRFProvider(form.HMACCSRFOptions{
+//	    Key:       csrfKey,
+//	    SessionID: session.ID,
+//	})
+//
+// It returns an error if the key is shorter than 32 bytes.
+func NewHMACCSRFProvider(opts HMACCSRFOptions) (CSRFProvider, error) {
+	if err := checkKey(opts.Key); err != nil {
+		return nil, err
+	}
+
+	provider := &hmacCSRFProvider{
+		key:       opts.Key,
+		sessionID: opts.SessionID,
+		maxAge:    opts.MaxAge,
+		now:       opts.Now,
+	}
+	if provider.maxAge <= 0 {
+		provider.maxAge = 12 * time.Hour
+	}
+	if provider.now == nil {
+		provider.now = time.Now
+	}
+	return provider, nil
+}
+
+// Token returns the issue time and a random nonce, signed for the session.
+func (provider *hmacCSRFProvider) Token() string {
+	payload := make([]byte, 8, 24)
+	binary.BigEndian.PutUint64(payload, uint64(provider.now().Unix()))
+	payload = append(payload, make([]byte, 16)...)
+	_, _ = rand.Read(payload[8:])
+
+	return signToken(provider.key, provider.purpose(), payload)
+}
+
+// Verify reports whether the token was signed with the key for the session
+// and is not older than MaxAge.
+func (provider *hmacCSRFProvider) Verify(token string) bool {
+	payload, ok := openToken(provider.key, provider.purpose(), token)
+	if !ok || len(payload) != 24 {
+		return false
+	}
+
+	issued := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	age := provider.now().Sub(issued)
+	return age >= -time.Minute && age <= provider.maxAge
+}
+
+// purpose binds the signatures to CSRF tokens of the session.
+func (provider *hmacCSRFProvider) purpose() string {
+	return "csrf\x00" + provider.sessionID
+}
+
+// WithCSRF protects the form against cross-site request forgery. Build
+// renders a token from the provider as a hidden input and, for HTMX
+// requests, as the hx-headers of the form and of repeater buttons.
+// ParseRequest rejects requests without a valid token with ErrInvalidCSRFToken.
+func (form *Form) WithCSRF(provider CSRFProvider) *Form {
+	form.csrf = provider
+	return form
+}
+
+// verifyCSRF checks the token of a parsed request, read from the
+// CSRFHeaderName header or the CSRFFieldName value.
+func (form *Form) verifyCSRF(r *http.Request) error {
+	if form.csrf == nil {
+		return nil
+	}
+
+	token := r.Header.Get(CSRFHeaderName)
+	if token == "" {
+		token = r.Form.Get(CSRFFieldName)
+	}
+
+	if token == "" || !form.csrf.Verify(token) {
+		return ErrInvalidCSRFToken
+	}
+	return nil
+}
+
+// csrfInput returns the hidden input holding the form's CSRF token.
+func (form *Form) csrfInput() *hb.Tag {
+	return hb.NewInput().
+		Type(hb.TYPE_HIDDEN).
+		Name(CSRFFieldName).
+		Value(form.csrfToken)
+}
+
+// csrfHeaders returns the hx-headers value sending the form's CSRF token,
+// or "" if the form has no token.
+func (form *Form) csrfHeaders() string {
+	if form == nil || form.csrfToken == "" {
+		return ""
+	}
+	headers, _ := json.Marshal(map[string]string{CSRFHeaderName: form.csrfToken})
+	return string(headers)
+}
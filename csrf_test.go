@@ -0,0 +1,180 @@
+package form
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testCSRFKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testCSRFProvider(sessionID string, now time.Time) CSRFProvider {
+	provider, err := NewHMACCSRFProvider(HMACCSRFOptions{
+		Key:       testCSRFKey,
+		SessionID: sessionID,
+		Now:       func() time.Time { return now },
+	})
+	if err != nil {
+		panic(err)
+	}
+	return provider
+}
+
+// tamper changes a character in the middle of the token's payload.
+func tamper(token string) string {
+	i := strings.Index(token, ".") / 2
+	replacement := "A"
+	if token[i] == 'A' {
+		replacement = "B"
+	}
+	return token[:i] + replacement + token[i+1:]
+}
+
+func TestHMACCSRFProvider(t *testing.T) {
+	issued := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	token := testCSRFProvider("session-1", issued).Token()
+
+	otherKey, err := NewHMACCSRFProvider(HMACCSRFOptions{Key: []byte("another secret of at least 32 bytes"), SessionID: "session-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token == testCSRFProvider("session-1", issued).Token() {
+		t.Fatal("Expected every token to be different")
+	}
+
+	tests := []struct {
+		name     string
+		provider CSRFProvider
+		token    string
+		valid    bool
+	}{
+		{"same session", testCSRFProvider("session-1", issued.Add(time.Hour)), token, true},
+		{"other session", testCSRFProvider("session-2", issued), token, false},
+		{"expired", testCSRFProvider("session-1", issued.Add(13*time.Hour)), token, false},
+		{"issued in the future", testCSRFProvider("session-1", issued.Add(-time.Hour)), token, false},
+		{"other key", otherKey, token, false},
+		{"tampered", testCSRFProvider("session-1", issued), tamper(token), false},
+		{"no signature", testCSRFProvider("session-1", issued), strings.Split(token, ".")[0], false},
+		{"garbage", testCSRFProvider("session-1", issued), "not.a-token!", false},
+		{"empty", testCSRFProvider("session-1", issued), "", false},
+	}
+
+	for _, test := range tests {
+		if test.provider.Verify(test.token) != test.valid {
+			t.Errorf("%s: expected valid %v", test.name, test.valid)
+		}
+	}
+}
+
+func TestNewHMACCSRFProviderShortKey(t *testing.T) {
+	for _, key := range [][]byte{nil, []byte("short"), testCSRFKey[:31]} {
+		if provider, err := NewHMACCSRFProvider(HMACCSRFOptions{Key: key}); err == nil || provider != nil {
+			t.Fatal("Expected an error for a key of", len(key), "bytes")
+		}
+	}
+}
+
+func TestFormBuildRendersCSRFToken(t *testing.T) {
+	f := New().WithID("order").WithCSRF(testCSRFProvider("s", time.Now())).WithFields(
+		NewStringField("name", "Name"),
+	)
+
+	page := f.Build().ToHTML()
+	token := regexp.MustCompile(`name="csrf_token"[^>]* value="([^"]+)"`).FindStringSubmatch(page)
+	if token == nil || !f.csrf.Verify(token[1]) {
+		t.Fatal("Expected a valid hidden token, got:", page)
+	}
+	if strings.Contains(page, "hx-headers") {
+		t.Fatal("Expected no hx-headers on a form not submitted by HTMX, got:", page)
+	}
+
+	page = f.WithHxPost("/order").Build().ToHTML()
+	headers := regexp.MustCompile(`<form[^>]* hx-headers="([^"]+)"`).FindStringSubmatch(page)
+	if headers == nil || html.UnescapeString(headers[1]) != `{"X-CSRF-Token":"`+f.csrfToken+`"}` {
+		t.Fatal("Expected the token in the form's hx-headers, got:", page)
+	}
+}
+
+func TestRepeaterButtonsSendCSRFToken(t *testing.T) {
+	f := New().WithID("order").WithCSRF(testCSRFProvider("s", time.Now())).WithFields(
+		NewFieldRow(
+			NewRepeater(RepeaterOptions{
+				Name:        "items",
+				Fields:      []FieldInterface{NewStringField("sku", "SKU")},
+				Values:      []map[string]string{{"sku": "A"}},
+				RepeaterUrl: "/repeater",
+			}),
+		),
+	)
+
+	page := f.Build().ToHTML()
+	if count := strings.Count(page, `hx-headers="{&#34;X-CSRF-Token&#34;:&#34;`+f.csrfToken); count != 4 {
+		t.Fatal("Expected the token on the add, remove, move up and move down buttons, got:", page)
+	}
+	if !strings.Contains(page, `hx-include="#order"`) {
+		t.Fatal("Expected a repeater in a row to know the form, got:", page)
+	}
+}
+
+func TestParseRequestVerifiesCSRFToken(t *testing.T) {
+	provider := testCSRFProvider("s", time.Now())
+	newForm := func() *Form {
+		return New().WithCSRF(provider).WithFields(NewStringField("name", "Name"))
+	}
+	newRequest := func(values url.Values) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	f := newForm()
+	if _, err := f.ParseRequest(newRequest(url.Values{"name": {"Jane"}})); !errors.Is(err, ErrInvalidCSRFToken) {
+		t.Fatal("Expected a missing token to fail, got:", err)
+	}
+	if f.findField("name").GetValue() != "" {
+		t.Fatal("Expected the values not to be parsed")
+	}
+
+	if _, err := newForm().ParseRequest(newRequest(url.Values{"name": {"Jane"}, CSRFFieldName: {"forged"}})); !errors.Is(err, ErrInvalidCSRFToken) {
+		t.Fatal("Expected an invalid token to fail, got:", err)
+	}
+
+	values, err := newForm().ParseRequest(newRequest(url.Values{"name": {"Jane"}, CSRFFieldName: {provider.Token()}}))
+	if err != nil || values["name"] != "Jane" {
+		t.Fatal("Expected a token in the body to pass, got:", values, err)
+	}
+	if _, found := values[CSRFFieldName]; found {
+		t.Fatal("Expected the token not to be returned as a value")
+	}
+
+	r := newRequest(url.Values{"name": {"Jane"}})
+	r.Header.Set(CSRFHeaderName, provider.Token())
+	if _, err := newForm().ParseRequest(r); err != nil {
+		t.Fatal("Expected a token in the header to pass, got:", err)
+	}
+}
+
+func TestRepeaterControllerRejectsInvalidCSRFToken(t *testing.T) {
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm: func(r *http.Request) *Form {
+			return newControllerForm(r).WithCSRF(testCSRFProvider("s", time.Now()))
+		},
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/repeater?repeatable_add=1", strings.NewReader(threeItems().Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	controller.ServeHTTP(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatal("Expected status 403, got:", w.Code, w.Body.String())
+	}
+}
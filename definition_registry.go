@@ -0,0 +1,455 @@
+package form
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dracory/hb"
+)
+
+// RuleFactory creates a rule from the params stored in a form definition.
+type RuleFactory func(params map[string]string) (Rule, error)
+
+// definitionRegistry holds the named values form definitions refer to:
+// everything that cannot be stored as data, such as funcs and tags.
+type definitionRegistry struct {
+	mu                sync.RWMutex
+	rules             map[string]RuleFactory
+	optionsFuncs      map[string]func() []FieldOption
+	contextValidators map[string]ContextValidator
+	sanitizers        map[string]Sanitizer
+	tags              map[string]hb.TagInterface
+	themes            map[string]*Theme
+	messages          map[string]MessageProvider
+}
+
+var registry = &definitionRegistry{
+	rules:             builtinRuleFactories(),
+	optionsFuncs:      map[string]func() []FieldOption{},
+	contextValidators: map[string]ContextValidator{},
+	sanitizers: map[string]Sanitizer{
+		"trim":            SanitizerTrim(),
+		"lower":           SanitizerLower(),
+		"upper":           SanitizerUpper(),
+		"collapse_spaces": SanitizerCollapseSpaces(),
+		"digits":          SanitizerDigits(),
+		"nfc":             SanitizerNFC(),
+		"strip_html":      SanitizerStripHTML(),
+		"strip_control":   SanitizerStripControl(),
+	},
+	tags: map[string]hb.TagInterface{},
+	themes: map[string]*Theme{
+		"bootstrap5": ThemeBootstrap5(),
+		"tailwind":   ThemeTailwind(),
+	},
+	messages: map[string]MessageProvider{},
+}
+
+// RegisterRule registers the factory of a rule, so definitions can store rules
+// with the name by their params. Built-in rules are registered already.
+// Registering a name again replaces it.
+func RegisterRule(name string, factory RuleFactory) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.rules[name] = factory
+}
+
+// RegisterOptionsFunc registers an options function under a name. Fields use
+// it with Field.WithOptionsFunc, so definitions can store it by name.
+// Registering a name again replaces it.
+func RegisterOptionsFunc(name string, optionsF func() []FieldOption) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.optionsFuncs[name] = optionsF
+}
+
+// RegisterContextValidator registers a context validator under a name and
+// returns it wrapped, carrying the name. Use the returned validator on fields,
+// so definitions can store it by name. Registering a name again replaces it.
+func RegisterContextValidator(name string, validator ContextValidator) ContextValidator {
+	named := (&namedContextValidator{name: name, validator: validator}).validate
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.contextValidators[name] = named
+	return named
+}
+
+// RegisterSanitizer registers a sanitizer under a name and returns it wrapped,
+// carrying the name. Use the returned sanitizer on fields, so definitions can
+// store it by name. The built-in sanitizers are registered already, e.g. "trim"
+// and "strip_html". Registering a name again replaces it.
+func RegisterSanitizer(name string, sanitizer Sanitizer) Sanitizer {
+	named := newNamedSanitizer(name, sanitizer)
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.sanitizers[name] = named
+	return named
+}
+
+// RegisterTag registers an HTML tag under a name, so definitions can store
+// fields' CustomInput and the table row buttons by name.
+// Registering a name again replaces it.
+func RegisterTag(name string, tag hb.TagInterface) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.tags[name] = tag
+}
+
+// RegisterTheme registers a theme under a name, so definitions can store the
+// form's theme by name. "bootstrap5" and "tailwind" are registered already.
+// Registering a name again replaces it.
+func RegisterTheme(name string, theme *Theme) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.themes[name] = theme
+}
+
+// RegisterMessages registers a message provider under a name, so definitions
+// can store the form's validation messages by name.
+// Registering a name again replaces it.
+func RegisterMessages(name string, messages MessageProvider) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.messages[name] = messages
+}
+
+// NewRule creates a rule with a name and params, validating with the function.
+// Register a factory for the name with RegisterRule to store the rule in
+// form definitions.
+func NewRule(name string, params map[string]string, validate func(fieldName string, value string, values map[string]string) *ValidationError) Rule {
+	return newRule(name, params, validate)
+}
+
+// rule returns the rule with the name created from the params.
+func (r *definitionRegistry) rule(name string, params map[string]string) (Rule, error) {
+	r.mu.RLock()
+	factory, ok := r.rules[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.New("rule " + name + " is not registered")
+	}
+
+	rule, err := factory(params)
+	if err != nil {
+		return nil, errors.New("rule " + name + ": " + err.Error())
+	}
+
+	return rule, nil
+}
+
+// hasRule reports whether a factory is registered for the rule name.
+func (r *definitionRegistry) hasRule(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.rules[name]
+	return ok
+}
+
+// registryLookup returns the value registered under the name.
+func registryLookup[V any](r *definitionRegistry, entries map[string]V, kind string, name string) (V, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	value, ok := entries[name]
+	if !ok {
+		var zero V
+		return zero, errors.New(kind + " " + name + " is not registered")
+	}
+
+	return value, nil
+}
+
+// registryName returns the name the value is registered under, comparing
+// values with same. Values registered under several names are reported as
+// ambiguous. Funcs cannot be compared, so registered funcs carry their name.
+func registryName[V any](r *definitionRegistry, entries map[string]V, kind string, value V, same func(a V, b V) bool) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := []string{}
+	for name, registered := range entries {
+		if same(registered, value) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	switch len(names) {
+	case 0:
+		return "", errors.New(kind + " is not registered")
+	case 1:
+		return names[0], nil
+	}
+
+	return "", errors.New(kind + " is registered under several names: " + strings.Join(names, ", "))
+}
+
+// sameValue reports whether two values are identical: maps, slices and
+// pointers by their address, other comparable values by equality.
+// Funcs are never identical.
+func sameValue(a any, b any) bool {
+	va := reflect.ValueOf(a)
+	vb := reflect.ValueOf(b)
+
+	if !va.IsValid() || !vb.IsValid() {
+		return !va.IsValid() && !vb.IsValid()
+	}
+
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	switch va.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return va.Pointer() == vb.Pointer()
+	}
+
+	if !va.Comparable() {
+		return false
+	}
+
+	return va.Equal(vb)
+}
+
+// Registered funcs are wrapped in bound methods of a value holding their
+// name. A wrapper is told apart from other funcs by its code pointer, which
+// is the same for all bound methods of one method, and reports its name when
+// called with a probe, so other funcs are never called.
+
+// namedOptionsFunc is the options function registered under a name.
+// Fields keep the name, see Field.WithOptionsFunc.
+type namedOptionsFunc struct {
+	name string
+}
+
+var namedOptionsFuncCode = reflect.ValueOf((&namedOptionsFunc{}).options).Pointer()
+
+// options returns the options of the function registered under the name,
+// or none if no function is registered under it.
+func (named *namedOptionsFunc) options() []FieldOption {
+	optionsF, err := registryLookup(registry, registry.optionsFuncs, "options function", named.name)
+	if err != nil {
+		return nil
+	}
+	return optionsF()
+}
+
+// optionsFuncName returns the name of the registered options function set
+// on the field with WithOptionsFunc.
+func optionsFuncName(field *Field) (string, bool) {
+	if field.optionsFunc == "" || field.OptionsF == nil || reflect.ValueOf(field.OptionsF).Pointer() != namedOptionsFuncCode {
+		return "", false
+	}
+	return field.optionsFunc, true
+}
+
+// namedContextValidator is a context validator registered under a name.
+type namedContextValidator struct {
+	name      string
+	validator ContextValidator
+}
+
+// contextValidatorProbe is the context key of the probe asking a registered
+// context validator for its name.
+type contextValidatorProbe struct{}
+
+var namedContextValidatorCode = reflect.ValueOf((&namedContextValidator{}).validate).Pointer()
+
+// validate runs the validator, or reports its name to a probe.
+func (named *namedContextValidator) validate(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+	if name, ok := ctx.Value(contextValidatorProbe{}).(*string); ok {
+		*name = named.name
+		return nil, nil
+	}
+	return named.validator(ctx, fieldName, value, values)
+}
+
+// contextValidatorName returns the name a registered context validator carries.
+func contextValidatorName(validator ContextValidator) (string, bool) {
+	if validator == nil || reflect.ValueOf(validator).Pointer() != namedContextValidatorCode {
+		return "", false
+	}
+
+	name := ""
+	_, _ = validator(context.WithValue(context.Background(), contextValidatorProbe{}, &name), "", "", nil)
+	return name, true
+}
+
+// namedSanitizer is a sanitizer registered under a name.
+type namedSanitizer struct {
+	name      string
+	sanitizer Sanitizer
+}
+
+// sanitizerProbe is the value of the probe asking a registered sanitizer for
+// its name. It is random, so a submitted value cannot be taken for it.
+var sanitizerProbe = "\x00sanitizer-probe\x00" + rand.Text()
+
+var namedSanitizerCode = reflect.ValueOf((&namedSanitizer{}).sanitize).Pointer()
+
+// newNamedSanitizer returns the sanitizer wrapped, carrying the name.
+func newNamedSanitizer(name string, sanitizer Sanitizer) Sanitizer {
+	return (&namedSanitizer{name: name, sanitizer: sanitizer}).sanitize
+}
+
+// sanitize runs the sanitizer, or reports its name to a probe.
+func (named *namedSanitizer) sanitize(value string) string {
+	if value == sanitizerProbe {
+		return named.name
+	}
+	return named.sanitizer(value)
+}
+
+// sanitizerName returns the name a registered sanitizer carries.
+func sanitizerName(sanitizer Sanitizer) (string, bool) {
+	if sanitizer == nil || reflect.ValueOf(sanitizer).Pointer() != namedSanitizerCode {
+		return "", false
+	}
+	return sanitizer(sanitizerProbe), true
+}
+
+// builtinRuleFactories returns the factories of the built-in rules.
+func builtinRuleFactories() map[string]RuleFactory {
+	noParams := func(constructor func() Rule) RuleFactory {
+		return func(map[string]string) (Rule, error) {
+			return constructor(), nil
+		}
+	}
+
+	intParam := func(key string, constructor func(int) Rule) RuleFactory {
+		return func(params map[string]string) (Rule, error) {
+			n, err := strconv.Atoi(params[key])
+			if err != nil {
+				return nil, errors.New("invalid " + key + " " + strconv.Quote(params[key]))
+			}
+			return constructor(n), nil
+		}
+	}
+
+	floatParam := func(key string, constructor func(float64) Rule) RuleFactory {
+		return func(params map[string]string) (Rule, error) {
+			n, err := strconv.ParseFloat(params[key], 64)
+			if err != nil {
+				return nil, errors.New("invalid " + key + " " + strconv.Quote(params[key]))
+			}
+			return constructor(n), nil
+		}
+	}
+
+	otherParam := func(constructor func(string) Rule) RuleFactory {
+		return func(params map[string]string) (Rule, error) {
+			if params["other"] == "" {
+				return nil, errors.New("missing other")
+			}
+			return constructor(params["other"]), nil
+		}
+	}
+
+	conditionParam := func(constructor func(string, ...string) Rule) RuleFactory {
+		return func(params map[string]string) (Rule, error) {
+			if params["other"] == "" {
+				return nil, errors.New("missing other")
+			}
+			values, err := parseListParam(params["values"])
+			if err != nil {
+				return nil, err
+			}
+			return constructor(params["other"], values...), nil
+		}
+	}
+
+	dateParam := func(key string, constructor func(DateRef) Rule) RuleFactory {
+		return func(params map[string]string) (Rule, error) {
+			date, err := parseDateRef(params[key])
+			if err != nil {
+				return nil, err
+			}
+			return constructor(date), nil
+		}
+	}
+
+	return map[string]RuleFactory{
+		RuleRequired:     noParams(ValidatorRequired),
+		RuleMinLength:    intParam("min", ValidatorMinLength),
+		RuleMaxLength:    intParam("max", ValidatorMaxLength),
+		RuleMin:          floatParam("min", ValidatorMin),
+		RuleMax:          floatParam("max", ValidatorMax),
+		RuleInteger:      noParams(ValidatorInteger),
+		RuleEmail:        noParams(ValidatorEmail),
+		RuleURL:          noParams(ValidatorURL),
+		RuleIP:           noParams(ValidatorIP),
+		RuleUUID:         noParams(ValidatorUUID),
+		RuleAlphaNumeric: noParams(ValidatorAlphaNumeric),
+		RulePattern: func(params map[string]string) (Rule, error) {
+			if !isValidPattern(params["pattern"]) {
+				return nil, errors.New("invalid pattern " + strconv.Quote(params["pattern"]))
+			}
+			return ValidatorPattern(params["pattern"], params["message"]), nil
+		},
+		RuleOneOf: func(params map[string]string) (Rule, error) {
+			values, err := parseListParam(params["values"])
+			if err != nil {
+				return nil, err
+			}
+			return ValidatorOneOf(values...), nil
+		},
+		RuleSameAs:           otherParam(ValidatorSameAs),
+		RuleGreaterThanField: otherParam(ValidatorGreaterThanField),
+		RuleRequiredIf:       conditionParam(ValidatorRequiredIf),
+		RuleRequiredUnless:   conditionParam(ValidatorRequiredUnless),
+		RuleDate: func(params map[string]string) (Rule, error) {
+			return ValidatorDate(params["layout"]), nil
+		},
+		RuleDateAfter:  dateParam("min", ValidatorDateAfter),
+		RuleDateBefore: dateParam("max", ValidatorDateBefore),
+		RuleDateBetween: func(params map[string]string) (Rule, error) {
+			min, err := parseDateRef(params["min"])
+			if err != nil {
+				return nil, err
+			}
+			max, err := parseDateRef(params["max"])
+			if err != nil {
+				return nil, err
+			}
+			return ValidatorDateBetween(min, max), nil
+		},
+		RuleIPv6:         noParams(ValidatorIPv6),
+		RuleCIDR:         noParams(ValidatorCIDR),
+		RuleHostname:     noParams(ValidatorHostname),
+		RuleE164:         noParams(ValidatorE164),
+		RuleIBAN:         noParams(ValidatorIBAN),
+		RuleISBN:         noParams(ValidatorISBN),
+		RuleSemver:       noParams(ValidatorSemver),
+		RuleSlug:         noParams(ValidatorSlug),
+		RuleJSON:         noParams(ValidatorJSON),
+		RuleHexColor:     noParams(ValidatorHexColor),
+		RuleEmailAddress: noParams(ValidatorEmailAddress),
+		RuleMinGraphemes: intParam("min", ValidatorMinGraphemes),
+		RuleMaxGraphemes: intParam("max", ValidatorMaxGraphemes),
+		RuleMaxBytes:     intParam("max", ValidatorMaxBytes),
+		RuleConfusable:   noParams(ValidatorNoConfusables),
+		RuleCreditCard: func(params map[string]string) (Rule, error) {
+			brands, err := parseListParam(params["brands"])
+			if err != nil {
+				return nil, err
+			}
+			return ValidatorCreditCard(brands...), nil
+		},
+		RuleWeekday: func(params map[string]string) (Rule, error) {
+			days, err := parseWeekdays(params["days"])
+			if err != nil {
+				return nil, err
+			}
+			return ValidatorWeekday(days...), nil
+		},
+	}
+}
@@ -0,0 +1,201 @@
+package form
+
+import (
+	"regexp/syntax"
+	"strings"
+)
+
+// constraintAttrs returns the HTML5 constraint attributes of the field:
+// required from the Required flag, and minlength, maxlength, min, max, step
+// and pattern from its rules, including the min and max of date rules, so the browser runs the same checks as Validate.
+// Attributes the field's input type does not support are left out. Number
+// fields with a min or max but no integer rule get step="any", as the
+// browser's default step of 1 would reject the decimals Validate accepts.
+func (field *Field) constraintAttrs() map[string]string {
+	attrs := map[string]string{}
+
+	if field.Required && field.Type != FORM_FIELD_TYPE_HIDDEN {
+		attrs["required"] = "required"
+	}
+
+	for _, rule := range field.GetRules() {
+		if dateRule, ok := rule.(*dateRule); ok {
+			if field.supportsDateConstraints() {
+				for key, value := range dateRule.constraintAttrs(field.Type) {
+					attrs[key] = value
+				}
+			}
+			continue
+		}
+
+		params := rule.Params()
+
+		switch rule.Name() {
+		case RuleRequired:
+			if field.Type != FORM_FIELD_TYPE_HIDDEN {
+				attrs["required"] = "required"
+			}
+		case RuleMinLength:
+			if field.supportsTextConstraints() {
+				attrs["minlength"] = params["min"]
+			}
+		case RuleMaxLength:
+			if field.supportsTextConstraints() {
+				attrs["maxlength"] = params["max"]
+			}
+		case RuleMin:
+			if field.supportsRangeConstraints() {
+				attrs["min"] = params["min"]
+			}
+		case RuleMax:
+			if field.supportsRangeConstraints() {
+				attrs["max"] = params["max"]
+			}
+		case RuleInteger:
+			if field.Type == FORM_FIELD_TYPE_NUMBER {
+				attrs["step"] = "1"
+			}
+		case RulePattern:
+			if field.supportsPatternConstraint() && isBrowserPattern(params["pattern"]) {
+				attrs["pattern"] = params["pattern"]
+			}
+		}
+	}
+
+	_, hasMin := attrs["min"]
+	_, hasMax := attrs["max"]
+	_, hasStep := attrs["step"]
+	if field.Type == FORM_FIELD_TYPE_NUMBER && (hasMin || hasMax) && !hasStep {
+		attrs["step"] = "any"
+	}
+
+	return attrs
+}
+
+// supportsConstraintAttrs reports whether fieldInput returns the field's
+// control itself, so the constraint attributes can be set on it. Checkboxes
+// and radios set the required attribute on their inputs themselves.
+func (field *Field) supportsConstraintAttrs() bool {
+	switch field.Type {
+	case FORM_FIELD_TYPE_STRING, FORM_FIELD_TYPE_PASSWORD, FORM_FIELD_TYPE_EMAIL,
+		FORM_FIELD_TYPE_TEL, FORM_FIELD_TYPE_URL, FORM_FIELD_TYPE_NUMBER,
+		FORM_FIELD_TYPE_DATE, FORM_FIELD_TYPE_DATETIME, FORM_FIELD_TYPE_SELECT,
+		FORM_FIELD_TYPE_TEXTAREA:
+		return true
+	}
+	return false
+}
+
+// supportsTextConstraints reports whether the field's input supports minlength and maxlength.
+func (field *Field) supportsTextConstraints() bool {
+	switch field.Type {
+	case FORM_FIELD_TYPE_STRING, FORM_FIELD_TYPE_PASSWORD, FORM_FIELD_TYPE_EMAIL,
+		FORM_FIELD_TYPE_TEL, FORM_FIELD_TYPE_URL, FORM_FIELD_TYPE_TEXTAREA:
+		return true
+	}
+	return false
+}
+
+// supportsRangeConstraints reports whether the field's input supports min and max.
+func (field *Field) supportsRangeConstraints() bool {
+	switch field.Type {
+	case FORM_FIELD_TYPE_NUMBER, FORM_FIELD_TYPE_DATE, FORM_FIELD_TYPE_DATETIME:
+		return true
+	}
+	return false
+}
+
+// supportsDateConstraints reports whether the field's input takes a date min and max.
+func (field *Field) supportsDateConstraints() bool {
+	return field.Type == FORM_FIELD_TYPE_DATE || field.Type == FORM_FIELD_TYPE_DATETIME
+}
+
+// supportsPatternConstraint reports whether the field's input supports pattern.
+func (field *Field) supportsPatternConstraint() bool {
+	switch field.Type {
+	case FORM_FIELD_TYPE_STRING, FORM_FIELD_TYPE_PASSWORD, FORM_FIELD_TYPE_EMAIL,
+		FORM_FIELD_TYPE_TEL, FORM_FIELD_TYPE_URL:
+		return true
+	}
+	return false
+}
+
+// isBrowserPattern reports whether a pattern can be passed to the browser as
+// the pattern attribute. Browsers match the whole value against it, in
+// JavaScript syntax, while Validate runs it with Go syntax and finds a match
+// anywhere in the value; both agree only for patterns anchored with ^ and $
+// around the whole expression, using the syntax the two languages share.
+func isBrowserPattern(pattern string) bool {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil || re.Op != syntax.OpConcat || len(re.Sub) < 2 {
+		return false
+	}
+	if re.Sub[0].Op != syntax.OpBeginText || re.Sub[len(re.Sub)-1].Op != syntax.OpEndText {
+		return false
+	}
+
+	return isJavaScriptPattern(pattern)
+}
+
+// isJavaScriptPattern reports whether the pattern avoids the Go syntax that
+// JavaScript lacks, such as flags and POSIX classes, and the characters the
+// browser's "v" flag requires escaping inside character classes.
+func isJavaScriptPattern(pattern string) bool {
+	inClass := false
+	classStart := false
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		rest := pattern[i:]
+
+		if c == '\\' {
+			if i+1 >= len(pattern) {
+				return false
+			}
+			next := pattern[i+1]
+			if strings.IndexByte("AzQEC", next) >= 0 {
+				return false
+			}
+			if (next == 'p' || next == 'P') && !strings.HasPrefix(pattern[i+2:], "{") {
+				return false
+			}
+			i++
+			classStart = false
+			continue
+		}
+
+		if inClass {
+			switch {
+			case c == ']' && !classStart:
+				inClass = false
+			case strings.IndexByte("()[]{}/|", c) >= 0:
+				return false
+			case c == '-' && (classStart || strings.HasPrefix(rest, "-]")):
+				return false
+			case len(rest) > 1 && rest[1] == c && strings.IndexByte("&!#$%*+,.:;<=>?@^`~", c) >= 0:
+				return false
+			}
+			classStart = false
+			continue
+		}
+
+		switch {
+		case c == '[':
+			if strings.HasPrefix(rest, "[[:") {
+				return false
+			}
+			inClass = true
+			classStart = true
+			if strings.HasPrefix(rest, "[^") {
+				i++
+			}
+		case strings.HasPrefix(rest, "(?"):
+			named := strings.HasPrefix(rest, "(?<") && !strings.HasPrefix(rest, "(?<=") && !strings.HasPrefix(rest, "(?<!")
+			if !strings.HasPrefix(rest, "(?:") && !named {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,185 @@
+package form
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConstraintAttrsFromValidators(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("username", "Username").WithRequired().WithValidators(
+			ValidatorMinLength(3),
+			ValidatorMaxLength(20),
+			ValidatorPattern(`^[a-z]+$`, ""),
+		),
+		NewNumberField("age", "Age").WithValidators(ValidatorMin(18), ValidatorMax(120.5)),
+		NewTextAreaField("bio", "Bio").WithValidators(ValidatorMaxLength(500)),
+	)
+
+	html := f.Build().ToHTML()
+
+	expecteds := []string{
+		`maxlength="20" minlength="3" name="username" pattern="^[a-z]+$" required="required" type="text"`,
+		`max="120.5" min="18" name="age" step="any" type="number"`,
+		`maxlength="500" name="bio"`,
+	}
+	for _, exp := range expecteds {
+		if !strings.Contains(html, exp) {
+			t.Fatal("Expected:", exp, "got:", html)
+		}
+	}
+}
+
+func TestConstraintAttrsNumberStep(t *testing.T) {
+	tests := []struct {
+		field    *Field
+		expected string
+	}{
+		{NewNumberField("price", "Price").WithValidators(ValidatorMin(0.5)), "any"},
+		{NewNumberField("price", "Price").WithValidators(ValidatorMax(10)), "any"},
+		{NewNumberField("quantity", "Quantity").WithValidators(ValidatorMin(1), ValidatorInteger()), "1"},
+		{NewNumberField("quantity", "Quantity").WithValidators(ValidatorInteger(), ValidatorMax(9)), "1"},
+		{NewNumberField("price", "Price"), ""},
+	}
+
+	for i, test := range tests {
+		if step := test.field.constraintAttrs()["step"]; step != test.expected {
+			t.Errorf("Test %d: expected step %q, got: %q", i, test.expected, step)
+		}
+	}
+
+	html := NewNumberField("price", "Price").WithValidators(ValidatorMin(0)).WithAttr("step", "0.01").BuildFormGroup("").ToHTML()
+	if !strings.Contains(html, `step="0.01"`) {
+		t.Fatal("Expected an explicit step to win, got:", html)
+	}
+}
+
+func TestConstraintAttrsRequiredValidator(t *testing.T) {
+	f := New().WithFields(
+		NewSelectField("country", "Country", []FieldOption{{Key: "de", Value: "Germany"}}).
+			WithValidators(ValidatorRequired()),
+	)
+
+	html := f.Build().ToHTML()
+
+	if !strings.Contains(html, `required="required"`) {
+		t.Fatal("Expected required attribute from ValidatorRequired, got:", html)
+	}
+}
+
+func TestConstraintAttrsUnsupportedTypes(t *testing.T) {
+	f := New().WithFields(
+		NewNumberField("quantity", "Quantity").WithValidators(ValidatorMinLength(2), ValidatorPattern(`\d+`, "")),
+		NewStringField("name", "Name").WithValidators(ValidatorMin(1)),
+		NewHiddenField("token", "abc").WithRequired(),
+	)
+
+	html := f.Build().ToHTML()
+
+	for _, unexpected := range []string{`minlength=`, `pattern=`, `min=`, `required=`} {
+		if strings.Contains(html, unexpected) {
+			t.Fatal("Unexpected attribute:", unexpected, "got:", html)
+		}
+	}
+}
+
+func TestConstraintAttrsCheckboxAndRadio(t *testing.T) {
+	f := New().WithFields(
+		NewCheckboxField("terms", "Terms").WithRequired(),
+		NewRadioField("plan", "Plan", []FieldOption{{Key: "free", Value: "Free"}, {Key: "pro", Value: "Pro"}}).WithRequired(),
+	)
+
+	html := f.Build().ToHTML()
+
+	if !strings.Contains(html, `name="terms" required="required" type="checkbox"`) {
+		t.Fatal("Expected required checkbox input, got:", html)
+	}
+	if strings.Count(html, `name="plan" required="required" type="radio"`) != 2 {
+		t.Fatal("Expected required radio inputs, got:", html)
+	}
+	if strings.Contains(html, `<div class="form-check" required`) || strings.Contains(html, `<div required`) {
+		t.Fatal("Should not set required on the wrappers, got:", html)
+	}
+}
+
+func TestConstraintAttrsSkipCustomValidators(t *testing.T) {
+	called := false
+	f := New().WithFields(
+		NewStringField("code", "Code").WithValidators(
+			ValidatorCustom(func(value string) string {
+				called = true
+				return "invalid"
+			}),
+			ValidatorSameAs("other"),
+		),
+	)
+
+	html := f.Build().ToHTML()
+
+	if called {
+		t.Fatal("Custom validator must not run while rendering")
+	}
+	if strings.Contains(html, `required=`) {
+		t.Fatal("Unexpected constraint attribute, got:", html)
+	}
+}
+
+func TestConstraintValidatorsStillValidate(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("code", "Code").WithValidators(ValidatorMinLength(3), ValidatorPattern(`^[a-z]+$`, "")),
+	)
+
+	errs := f.Validate(map[string]string{"code": "A"})
+	if len(errs) != 2 {
+		t.Fatal("Expected 2 errors, got:", errs)
+	}
+}
+
+func TestFormWithNoValidate(t *testing.T) {
+	f := New().WithNoValidate(true).WithFields(
+		NewStringField("name", "Name").WithRequired(),
+	)
+
+	html := f.Build().ToHTML()
+
+	if !strings.HasPrefix(html, `<form method="POST" novalidate="novalidate">`) {
+		t.Fatal("Expected novalidate form, got:", html)
+	}
+	if !strings.Contains(html, `required="required"`) {
+		t.Fatal("Expected constraint attributes to be kept, got:", html)
+	}
+
+	html = New().WithFields(NewStringField("name", "Name")).Build().ToHTML()
+	if strings.Contains(html, `novalidate`) {
+		t.Fatal("Should not render novalidate by default, got:", html)
+	}
+}
+
+func TestConstraintAttrsBrowserPatterns(t *testing.T) {
+	tests := map[string]bool{
+		`^[a-z]+$`:               true,
+		`^(?:a|b)[0-9]{2,4}$`:    true,
+		`^(?<year>\d{4})-\d{2}$`: true,
+		`^\p{L}+$`:               true,
+		`^[+\-]?\d+$`:            true,
+		`[a-z]+`:                 false, // unanchored: Validate finds "abc" in "abc1"
+		`^[a-z]+`:                false,
+		`^a|b$`:                  false, // the anchors belong to the alternatives
+		`^(?i)[a-z]+$`:           false,
+		`^(?P<year>\d{4})$`:      false,
+		`^[[:alpha:]]+$`:         false,
+		`^\pL+$`:                 false,
+		`\A[a-z]+\z`:             false,
+		`^[a-z-]+$`:              false, // the v flag requires escaping - at the end of a class
+		`^[a-z(]+$`:              false,
+		`^[]a]$`:                 false,
+		`^[a&&b]$`:               false,
+	}
+
+	for pattern, expected := range tests {
+		html := NewStringField("code", "Code").WithValidators(ValidatorPattern(pattern, "")).BuildFormGroup("").ToHTML()
+		if got := strings.Contains(html, ` pattern="`); got != expected {
+			t.Errorf("%s: expected the pattern attribute %v, got: %s", pattern, expected, html)
+		}
+	}
+}
@@ -83,6 +83,16 @@ func (field *Field) WithOptions(options ...FieldOption) *Field {
 // WithOptionsF sets a dynamic options provider function.
 func (field *Field) WithOptionsF(optionsF func() []FieldOption) *Field {
 	field.OptionsF = optionsF
+	field.optionsFunc = ""
+	return field
+}
+
+// WithOptionsFunc sets the options function registered under the name with
+// RegisterOptionsFunc as the dynamic options provider, so definitions can
+// store it by name. The function is looked up when the options are needed.
+func (field *Field) WithOptionsFunc(name string) *Field {
+	field.OptionsF = (&namedOptionsFunc{name: name}).options
+	field.optionsFunc = name
 	return field
 }
 
@@ -107,12 +117,34 @@ func (field *Field) WithAttrs(attrs map[string]string) *Field {
 	return field
 }
 
-// WithValidators sets the field's validators.
-func (field *Field) WithValidators(validators ...Validator) *Field {
+// WithValidators sets the field's validation rules. A func can be passed as a Validator.
+func (field *Field) WithValidators(validators ...Rule) *Field {
 	field.Validators = validators
 	return field
 }
 
+// WithContextValidators sets the field's context validators, run by Form.ValidateContext.
+func (field *Field) WithContextValidators(validators ...ContextValidator) *Field {
+	field.ContextValidators = validators
+	return field
+}
+
+// WithSanitizers sets the field's sanitizers, which clean up submitted values
+// before validation.
+func (field *Field) WithSanitizers(sanitizers ...Sanitizer) *Field {
+	field.Sanitizers = sanitizers
+	return field
+}
+
+// WithSigned makes the field tamper-proof: its value is rendered together
+// with a signature from the form's keyring, and a value whose signature does
+// not match fails validation. Meant for hidden fields holding values users
+// must not change, such as record IDs or prices.
+func (field *Field) WithSigned() *Field {
+	field.Signed = true
+	return field
+}
+
 // WithTableOptions sets the table options for table-type fields.
 func (field *Field) WithTableOptions(opts TableOptions) *Field {
 	field.TableOptions = opts
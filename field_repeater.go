@@ -21,6 +21,8 @@ import (
 
 type fieldRepeater struct {
 	form                *Form
+	keyring             Keyring
+	repeaterUrl         string
 	repeaterAddUrl      string
 	repeaterMoveUpUrl   string
 	repeaterMoveDownUrl string
@@ -33,17 +35,37 @@ type fieldRepeater struct {
 	fieldValue          string
 	fields              []FieldInterface
 	values              []map[string]string
+	itemIDs             []int // ID of each item, see itemID; nil while the values are as given
+	errors              map[string][]string
 }
 
 // == INTERFACE ===============================================================
 
 var _ FieldInterface = (*fieldRepeater)(nil)
 var _ formAware = (*fieldRepeater)(nil)
+var _ rowErrorAware = (*fieldRepeater)(nil)
+var _ keyringAware = (*fieldRepeater)(nil)
 
 func (field *fieldRepeater) setForm(form *Form) {
 	field.form = form
 }
 
+// setKeyring sets the keyring that signs and verifies the signed item fields.
+func (field *fieldRepeater) setKeyring(keyring Keyring) {
+	field.keyring = keyring
+	for _, itemField := range flattenFields(field.fields) {
+		if ka, ok := itemField.(keyringAware); ok {
+			ka.setKeyring(keyring)
+		}
+	}
+}
+
+// setFieldErrors sets the error map so the repeater can show errors on the item fields,
+// keyed by their input names, e.g. "addresses[city][2]".
+func (field *fieldRepeater) setFieldErrors(errors map[string][]string) {
+	field.errors = errors
+}
+
 // == IMPLEMENTATION OF FieldInterface ========================================
 
 func (field *fieldRepeater) clone() FieldInterface {
@@ -135,6 +157,10 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		return hb.Div().Class("alert alert-danger").Text("Form Error. Repeater has no name")
 	}
 
+	if field.repeaterUrl != "" {
+		field.applyRepeaterUrl()
+	}
+
 	if field.repeaterAddUrl == "" {
 		return hb.Div().Class("alert alert-danger").Text("Form Error. Repeater " + field.GetName() + " has no repeaterAddUrl")
 	}
@@ -143,6 +169,10 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		return hb.Div().Class("alert alert-danger").Text("Form Error. Repeater " + field.GetName() + " has no repeaterRemoveUrl")
 	}
 
+	if field.hasLockedFields() && field.keyring == nil {
+		return hb.Div().Class("alert alert-danger").Text("Form Error. Repeater " + field.GetName() + " has readonly or disabled fields and needs a keyring, see Form.WithKeyring")
+	}
+
 	if !strings.Contains(field.repeaterRemoveUrl, "?") {
 		field.repeaterRemoveUrl += `?`
 	}
@@ -164,6 +194,9 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 
 	formID := lo.IfF(field.form != nil, func() string { return field.form.id }).Else("")
 
+	// A RepeaterController responds with the whole form, which replaces the current one
+	swapForm := field.repeaterUrl != ""
+
 	buttonAdd := hb.NewButton().
 		Child(hb.I().Class("bi bi-plus")).
 		HTML(" Add new").
@@ -172,6 +205,13 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		HxPost(field.repeaterAddUrl).
 		HxTarget("#" + formID)
 
+	if swapForm {
+		buttonAdd.HxSwap(hb.SwapOuterHTML)
+	}
+
+	csrfHeaders := field.form.csrfHeaders()
+	buttonAdd.Hx("headers", csrfHeaders)
+
 	formGroupLabel := hb.NewLabel().
 		HTML(fieldLabel).
 		Class("form-label").
@@ -182,6 +222,7 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		Child(buttonAdd)
 
 	cards := hb.Wrap()
+	itemErrors := field.errors
 
 	for itemIndex, mapKeyValue := range field.values {
 		children := lo.Map(field.fields, func(field FieldInterface, fieldIndex int) hb.TagInterface {
@@ -191,14 +232,26 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 
 			fieldName := clonedField.GetName()
 			fieldRepeaterValue := lo.ValueOr(mapKeyValue, fieldName, "")
-			fieldRepeaterName := repeaterFieldName + `[` + fieldName + `][]` // + `[` + cast.ToString(index) + `]`
+			fieldRepeaterName := repeaterItemFieldName(repeaterFieldName, fieldName, itemIndex)
 
 			clonedField.SetName(fieldRepeaterName)
 			clonedField.SetValue(fieldRepeaterValue)
 
+			if ea, ok := clonedField.(errorAware); ok {
+				ea.setErrors(itemErrors[fieldRepeaterName])
+			}
+
 			return clonedField.BuildFormGroup(fileManagerURL)
 		})
 
+		// The item ID matches the item to the values of its locked fields
+		if field.hasLockedFields() {
+			children = append(children, hb.NewInput().
+				Type(hb.TYPE_HIDDEN).
+				Name(repeaterItemIDName(repeaterFieldName, itemIndex)).
+				Value(field.signedItemID(itemIndex)))
+		}
+
 		buttonRemove := hb.NewButton().
 			Child(hb.I().Class("bi bi-trash")).
 			Title("Delete").
@@ -226,6 +279,16 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 			HxTarget("#" + formID).
 			HxTrigger("click")
 
+		if swapForm {
+			buttonRemove.HxSwap(hb.SwapOuterHTML)
+			buttonMoveUp.HxSwap(hb.SwapOuterHTML)
+			buttonMoveDown.HxSwap(hb.SwapOuterHTML)
+		}
+
+		for _, button := range []*hb.Tag{buttonRemove, buttonMoveUp, buttonMoveDown} {
+			button.Hx("headers", csrfHeaders)
+		}
+
 		card := hb.NewDiv().
 			Class("card w-100 mb-3").
 			Child(hb.NewDiv().
@@ -245,3 +308,25 @@ func (field *fieldRepeater) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		Child(formGroupLabel).
 		Child(cards)
 }
+
+// applyRepeaterUrl derives the add, remove, move up and move down URLs that
+// were not set from the single repeater URL served by a RepeaterController.
+func (field *fieldRepeater) applyRepeaterUrl() {
+	separator := lo.Ternary(strings.Contains(field.repeaterUrl, "?"), "&", "?")
+
+	if field.repeaterAddUrl == "" {
+		field.repeaterAddUrl = field.repeaterUrl + separator + repeaterActionAdd + "=1"
+	}
+
+	if field.repeaterRemoveUrl == "" {
+		field.repeaterRemoveUrl = field.repeaterUrl
+	}
+
+	if field.repeaterMoveUpUrl == "" {
+		field.repeaterMoveUpUrl = field.repeaterUrl
+	}
+
+	if field.repeaterMoveDownUrl == "" {
+		field.repeaterMoveDownUrl = field.repeaterUrl
+	}
+}
@@ -0,0 +1,161 @@
+package form
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// Readonly and disabled item fields keep the values the repeater was given,
+// whatever is submitted. Items are matched to those values by an item ID
+// rendered with each item, so the values follow their item when items are
+// moved or removed. The item ID is the index of the item in the values the
+// repeater was created with, signed with the form's keyring.
+
+// repeaterItemIDName returns the name of the hidden input holding the ID of
+// a repeater item, in the "repeaterName__item[itemIndex]" format. It is kept
+// apart from the item field inputs, so it never shows up in the item values.
+func repeaterItemIDName(repeaterName string, itemIndex int) string {
+	return repeaterName + `__item[` + strconv.Itoa(itemIndex) + `]`
+}
+
+// isItemIDKey reports whether the key is the item ID input of one of the
+// repeater's items. Item IDs are only rendered for repeaters with locked fields.
+func (field *fieldRepeater) isItemIDKey(key string) bool {
+	prefix := field.GetName() + `__item[`
+	if !field.hasLockedFields() || !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, `]`) {
+		return false
+	}
+
+	index, err := strconv.Atoi(strings.TrimSuffix(key[len(prefix):], `]`))
+	return err == nil && index >= 0
+}
+
+// hasLockedFields reports whether any item field is readonly or disabled.
+func (field *fieldRepeater) hasLockedFields() bool {
+	for _, itemField := range flattenFields(field.fields) {
+		if isLockedField(itemField) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLockedField reports whether submitted values of the field are ignored.
+func isLockedField(field FieldInterface) bool {
+	f, ok := field.(*Field)
+	return ok && (f.Readonly || f.Disabled)
+}
+
+// itemID returns the ID of the item at the index, or -1 for an item added
+// since the repeater was given its values.
+func (field *fieldRepeater) itemID(index int) int {
+	if field.itemIDs == nil {
+		return index // the values were given by NewRepeater or SetValues
+	}
+	if index < len(field.itemIDs) {
+		return field.itemIDs[index]
+	}
+	return -1
+}
+
+// lockedItem returns the values of the item with the ID, or nil if the
+// repeater has no such item.
+func (field *fieldRepeater) lockedItem(id int) map[string]string {
+	if id < 0 {
+		return nil
+	}
+	for index, item := range field.values {
+		if field.itemID(index) == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// signedItemID returns the signed ID of the item at the index, rendered in
+// its item ID input, or "" for added items and repeaters without a keyring.
+func (field *fieldRepeater) signedItemID(index int) string {
+	id := field.itemID(index)
+	if id < 0 || field.keyring == nil {
+		return ""
+	}
+	return signWithKeyring(field.keyring, field.itemIDPurpose(), []byte(strconv.Itoa(id)))
+}
+
+// submittedItemIDs returns the IDs submitted for the items with the indexes,
+// -1 for items without a valid ID. An ID submitted for more than one item
+// is only kept for the first, so the values of an item cannot be copied.
+func (field *fieldRepeater) submittedItemIDs(values url.Values, indexes []int) []int {
+	ids := make([]int, len(indexes))
+	used := map[int]bool{}
+
+	for i, index := range indexes {
+		ids[i] = -1
+		if field.keyring == nil {
+			continue
+		}
+
+		payload, ok := openWithKeyring(field.keyring, field.itemIDPurpose(), values.Get(repeaterItemIDName(field.GetName(), index)))
+		if !ok {
+			continue
+		}
+
+		id, err := strconv.Atoi(string(payload))
+		if err != nil || id < 0 || used[id] {
+			continue
+		}
+
+		used[id] = true
+		ids[i] = id
+	}
+
+	return ids
+}
+
+// itemIDPurpose binds the item ID signatures to the repeater.
+func (field *fieldRepeater) itemIDPurpose() string {
+	return "repeater-item\x00" + field.GetName()
+}
+
+// keepLockedValues replaces the submitted values of readonly and disabled
+// item fields with those of the repeater's items, matched by their item
+// IDs, as in ParseValues. The values of items without a valid ID are removed.
+func (field *fieldRepeater) keepLockedValues(values url.Values) {
+	locked := map[string]bool{}
+	for _, itemField := range flattenFields(field.fields) {
+		if isLockedField(itemField) {
+			locked[itemField.GetName()] = true
+		}
+	}
+	if len(locked) == 0 {
+		return
+	}
+
+	_, indexes := repeaterFormItems(values, field.GetName())
+	ids := map[int]int{}
+	for i, id := range field.submittedItemIDs(values, indexes) {
+		ids[indexes[i]] = id
+	}
+
+	prefix := field.GetName() + `[`
+	for key := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		fieldName, index, ok := splitRepeaterKey(key[len(prefix):])
+		if !ok || !locked[fieldName] {
+			continue
+		}
+
+		item := field.lockedItem(lo.ValueOr(ids, index, -1))
+		if index < 0 || item == nil {
+			delete(values, key)
+			continue
+		}
+		values[key] = []string{item[fieldName]}
+	}
+}
@@ -0,0 +1,207 @@
+package form
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// repeaterItemFieldName returns the input name of a field in a repeater item,
+// in the "repeaterName[fieldName][itemIndex]" format.
+//
+// The explicit item index keeps the submitted values aligned with their item,
+// even when some inputs of an item (such as unchecked checkboxes) are not submitted.
+func repeaterItemFieldName(repeaterName string, fieldName string, itemIndex int) string {
+	return repeaterName + `[` + fieldName + `][` + strconv.Itoa(itemIndex) + `]`
+}
+
+// ParseRepeaterValues rebuilds the items of the named repeater from a submitted request,
+// in the []map[string]string shape accepted by RepeaterOptions.Values.
+// It returns no items if the request body cannot be parsed.
+func ParseRepeaterValues(r *http.Request, repeaterName string) []map[string]string {
+	if err := parseRequestForm(r); err != nil {
+		return []map[string]string{}
+	}
+	return ParseRepeaterFormValues(r.Form, repeaterName)
+}
+
+// ParseRepeaterFormValues rebuilds the items of the named repeater from submitted values.
+//
+// Both the indexed "repeaterName[field][0]" and the positional "repeaterName[field][]"
+// naming conventions are supported. Items are returned ordered by their index.
+func ParseRepeaterFormValues(values url.Values, repeaterName string) []map[string]string {
+	items, _ := repeaterFormItems(values, repeaterName)
+	return items
+}
+
+// repeaterFormItems rebuilds the items like ParseRepeaterFormValues, together
+// with the index each item was submitted under.
+func repeaterFormItems(values url.Values, repeaterName string) ([]map[string]string, []int) {
+	items := map[int]map[string]string{}
+	prefix := repeaterName + `[`
+
+	for key, submitted := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		fieldName, index, ok := splitRepeaterKey(key[len(prefix):])
+		if !ok {
+			continue
+		}
+
+		for position, value := range submitted {
+			itemIndex := index
+			if itemIndex < 0 {
+				itemIndex = position // positional naming, the nth value belongs to the nth item
+			}
+			if items[itemIndex] == nil {
+				items[itemIndex] = map[string]string{}
+			}
+			items[itemIndex][fieldName] = value
+		}
+	}
+
+	indexes := make([]int, 0, len(items))
+	for index := range items {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	result := make([]map[string]string, len(indexes))
+	for i, index := range indexes {
+		result[i] = items[index]
+	}
+
+	return result, indexes
+}
+
+// splitRepeaterKey splits the "field][index]" remainder of a repeater input name.
+// The returned index is -1 for the positional "field][]" form.
+func splitRepeaterKey(rest string) (fieldName string, index int, ok bool) {
+	fieldName, indexPart, found := strings.Cut(rest, `][`)
+	if !found || fieldName == "" || !strings.HasSuffix(indexPart, `]`) {
+		return "", 0, false
+	}
+
+	indexPart = strings.TrimSuffix(indexPart, `]`)
+	if indexPart == "" {
+		return fieldName, -1, true
+	}
+
+	index, err := strconv.Atoi(indexPart)
+	if err != nil || index < 0 {
+		return "", 0, false
+	}
+
+	return fieldName, index, true
+}
+
+// ParseRequest rebuilds the repeater's items from a submitted request and
+// sets them as the repeater's values. See ParseValues.
+func (field *fieldRepeater) ParseRequest(r *http.Request) ([]map[string]string, error) {
+	if err := parseRequestForm(r); err != nil {
+		return nil, err
+	}
+	return field.ParseValues(r.Form), nil
+}
+
+// ParseValues rebuilds the repeater's items from submitted values and sets them
+// as the repeater's values. Every item has a key for each of the repeater's fields,
+// so fields that were not submitted (such as unchecked checkboxes) get an empty value.
+// Values are cleaned up by the item fields' sanitizers, and the values of
+// signed item fields are verified like those of signed fields of the form.
+//
+// Readonly and disabled item fields keep the values of the repeater's item
+// with the submitted item ID, whatever was submitted, or get an empty value
+// for items without a valid item ID, such as added ones.
+func (field *fieldRepeater) ParseValues(values url.Values) []map[string]string {
+	items, indexes := repeaterFormItems(values, field.GetName())
+	itemFields := flattenFields(field.fields)
+	ids := field.submittedItemIDs(values, indexes)
+
+	lockedItems := make([]map[string]string, len(items))
+	for itemIndex := range items {
+		lockedItems[itemIndex] = field.lockedItem(ids[itemIndex])
+	}
+
+	for _, itemField := range itemFields {
+		if f, ok := itemField.(*Field); ok {
+			f.invalidSignatures = nil
+		}
+	}
+
+	for itemIndex, item := range items {
+		for _, itemField := range itemFields {
+			name := itemField.GetName()
+			if _, exists := item[name]; !exists {
+				item[name] = ""
+			}
+
+			if isLockedField(itemField) {
+				item[name] = lockedItems[itemIndex][name]
+				continue
+			}
+
+			// Signatures are made for the input name the item was rendered
+			// with, and recorded under the one it is returned with
+			if f, ok := itemField.(*Field); ok && f.Signed {
+				inputName := repeaterItemFieldName(field.GetName(), name, indexes[itemIndex])
+				key := repeaterItemFieldName(field.GetName(), name, itemIndex)
+				item[name] = f.openSigned(field.keyring, inputName, key, item[name])
+			}
+
+			sanitizeValue(itemField, name, item)
+		}
+	}
+
+	field.values = items
+	field.itemIDs = ids
+
+	return items
+}
+
+// GetValues returns the repeater's items.
+func (field *fieldRepeater) GetValues() []map[string]string {
+	return field.values
+}
+
+// SetValues replaces the repeater's items.
+func (field *fieldRepeater) SetValues(values []map[string]string) {
+	field.values = values
+	field.itemIDs = nil
+}
+
+// reorderItems replaces the items with those at the positions, keeping
+// their item IDs. A negative position adds an empty item.
+func (field *fieldRepeater) reorderItems(positions []int) {
+	values := make([]map[string]string, len(positions))
+	ids := make([]int, len(positions))
+
+	for i, position := range positions {
+		if position < 0 || position >= len(field.values) {
+			values[i] = map[string]string{}
+			ids[i] = -1
+			continue
+		}
+		values[i] = field.values[position]
+		ids[i] = field.itemID(position)
+	}
+
+	field.values = values
+	field.itemIDs = ids
+}
+
+// flattenValues returns the repeater's item values keyed by their input names,
+// in the "repeaterName[fieldName][itemIndex]" format.
+func (field *fieldRepeater) flattenValues() map[string]string {
+	flat := map[string]string{}
+	for itemIndex, item := range field.values {
+		for fieldName, value := range item {
+			flat[repeaterItemFieldName(field.GetName(), fieldName, itemIndex)] = value
+		}
+	}
+	return flat
+}
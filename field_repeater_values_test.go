@@ -0,0 +1,146 @@
+package form
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newTestRepeater() *fieldRepeater {
+	return NewRepeater(RepeaterOptions{
+		Name: "addresses",
+		Fields: []FieldInterface{
+			NewStringField("city", "City"),
+			NewCheckboxField("primary", "Primary"),
+		},
+		RepeaterAddUrl:    "/add",
+		RepeaterRemoveUrl: "/remove",
+	})
+}
+
+func TestRepeaterRendersIndexedNames(t *testing.T) {
+	repeater := newTestRepeater()
+	repeater.SetValues([]map[string]string{{"city": "Sofia"}, {"city": "Varna"}})
+
+	html := repeater.BuildFormGroup("").ToHTML()
+
+	for _, expected := range []string{
+		`name="addresses[city][0]"`,
+		`name="addresses[primary][0]"`,
+		`name="addresses[city][1]"`,
+		`name="addresses[primary][1]"`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Fatal("Expected:", expected, "got:", html)
+		}
+	}
+}
+
+func TestParseRepeaterFormValuesIndexed(t *testing.T) {
+	values := url.Values{
+		"addresses[city][0]":    {"Sofia"},
+		"addresses[city][1]":    {"Varna"},
+		"addresses[primary][1]": {"1"},
+		"other":                 {"ignored"},
+	}
+
+	items := ParseRepeaterFormValues(values, "addresses")
+
+	if len(items) != 2 {
+		t.Fatal("Expected 2 items, got:", items)
+	}
+	if items[0]["city"] != "Sofia" || items[0]["primary"] != "" {
+		t.Fatal("Unexpected first item:", items[0])
+	}
+	if items[1]["city"] != "Varna" || items[1]["primary"] != "1" {
+		t.Fatal("Unexpected second item:", items[1])
+	}
+}
+
+func TestParseRepeaterFormValuesPositional(t *testing.T) {
+	values := url.Values{
+		"addresses[city][]": {"Sofia", "Varna"},
+		"addresses[zip][]":  {"1000", "9000"},
+	}
+
+	items := ParseRepeaterFormValues(values, "addresses")
+
+	if len(items) != 2 {
+		t.Fatal("Expected 2 items, got:", items)
+	}
+	if items[0]["city"] != "Sofia" || items[0]["zip"] != "1000" {
+		t.Fatal("Unexpected first item:", items[0])
+	}
+	if items[1]["city"] != "Varna" || items[1]["zip"] != "9000" {
+		t.Fatal("Unexpected second item:", items[1])
+	}
+}
+
+func TestParseRepeaterFormValuesOrdersByIndex(t *testing.T) {
+	values := url.Values{
+		"addresses[city][10]": {"Burgas"},
+		"addresses[city][2]":  {"Varna"},
+		"addresses[city][x]":  {"ignored"},
+	}
+
+	items := ParseRepeaterFormValues(values, "addresses")
+
+	if len(items) != 2 || items[0]["city"] != "Varna" || items[1]["city"] != "Burgas" {
+		t.Fatal("Unexpected items:", items)
+	}
+}
+
+func TestParseRepeaterValuesRequest(t *testing.T) {
+	body := url.Values{"addresses[city][0]": {"Sofia"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	items := ParseRepeaterValues(r, "addresses")
+
+	if len(items) != 1 || items[0]["city"] != "Sofia" {
+		t.Fatal("Unexpected items:", items)
+	}
+}
+
+func TestRepeaterParseValuesFillsMissingFields(t *testing.T) {
+	repeater := newTestRepeater()
+
+	items := repeater.ParseValues(url.Values{
+		"addresses[city][0]":    {"Sofia"},
+		"addresses[primary][0]": {"1"},
+		"addresses[city][1]":    {"Varna"},
+	})
+
+	if len(items) != 2 {
+		t.Fatal("Expected 2 items, got:", items)
+	}
+	if v, ok := items[1]["primary"]; !ok || v != "" {
+		t.Fatal("Expected unchecked checkbox to be empty, got:", items[1])
+	}
+	if len(repeater.GetValues()) != 2 {
+		t.Fatal("Expected repeater values to be set")
+	}
+}
+
+func TestFormParseValuesWithRepeater(t *testing.T) {
+	repeater := newTestRepeater()
+	f := New().WithFields(NewStringField("name", "Name"), repeater)
+
+	values := f.ParseValues(url.Values{
+		"name":                  {"John"},
+		"addresses[city][0]":    {"Sofia"},
+		"addresses[primary][0]": {"on"},
+	})
+
+	if values["name"] != "John" {
+		t.Fatal("Unexpected values:", values)
+	}
+	if values["addresses[city][0]"] != "Sofia" || values["addresses[primary][0]"] != "on" {
+		t.Fatal("Expected flattened repeater values, got:", values)
+	}
+	if len(repeater.GetValues()) != 1 {
+		t.Fatal("Expected repeater items to be set, got:", repeater.GetValues())
+	}
+}
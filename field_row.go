@@ -13,7 +13,7 @@ type fieldRow struct {
 	columns        []FieldRowColumn
 	rowClass       string
 	theme          *Theme
-	errors         map[string]string
+	errors         map[string][]string
 	fileManagerURL string
 }
 
@@ -42,16 +42,26 @@ func (r *fieldRow) WithRowClass(class string) *fieldRow {
 var _ FieldInterface = (*fieldRow)(nil)
 var _ themeable = (*fieldRow)(nil)
 var _ rowErrorAware = (*fieldRow)(nil)
+var _ fieldContainer = (*fieldRow)(nil)
 
 func (r *fieldRow) setTheme(theme *Theme) {
 	r.theme = theme
 }
 
-// setErrors sets the error map so the row can distribute errors to child fields.
-func (r *fieldRow) setErrors(errors map[string]string) {
+// setFieldErrors sets the error map so the row can distribute errors to child fields.
+func (r *fieldRow) setFieldErrors(errors map[string][]string) {
 	r.errors = errors
 }
 
+// childFields returns the fields held by the row's columns.
+func (r *fieldRow) childFields() []FieldInterface {
+	fields := make([]FieldInterface, len(r.columns))
+	for i, col := range r.columns {
+		fields[i] = col.Field
+	}
+	return fields
+}
+
 // == IMPLEMENTATION OF FieldInterface ========================================
 
 func (r *fieldRow) clone() FieldInterface {
@@ -80,10 +90,13 @@ func (r *fieldRow) BuildFormGroup(fileManagerURL string) *hb.Tag {
 		// Pass errors to child field
 		if r.errors != nil {
 			if ea, ok := col.Field.(errorAware); ok {
-				if msg, exists := r.errors[col.Field.GetName()]; exists {
-					ea.setError(msg)
+				if messages, exists := r.errors[col.Field.GetName()]; exists {
+					ea.setErrors(messages)
 				}
 			}
+			if rea, ok := col.Field.(rowErrorAware); ok {
+				rea.setFieldErrors(r.errors)
+			}
 		}
 
 		colDiv := hb.NewDiv().Class(colClass).
@@ -0,0 +1,51 @@
+package form
+
+// WithKeyring sets the keyring that signs the values of the form's signed
+// fields, see Field.WithSigned, the item IDs of repeaters with readonly or
+// disabled fields, and the submission tokens, see WithIdempotency.
+func (form *Form) WithKeyring(keyring Keyring) *Form {
+	form.keyring = keyring
+	return form
+}
+
+func (field *Field) setKeyring(keyring Keyring) {
+	field.keyring = keyring
+}
+
+// renderedValue returns the value rendered in the field's input: the value
+// and its signature for signed fields, otherwise the value itself.
+func (field *Field) renderedValue() string {
+	if !field.Signed || field.keyring == nil {
+		return field.Value
+	}
+	return signWithKeyring(field.keyring, signedFieldPurpose(field.Name), []byte(field.Value))
+}
+
+// openSigned returns the value of a signed field submitted in the named
+// input, or "" if its signature is invalid, which is recorded under the key
+// for Validate to report. The key is the input name the value is returned
+// under, which differs from the submitted name for reordered repeater items.
+func (field *Field) openSigned(keyring Keyring, inputName string, key string, submitted string) string {
+	if field.invalidSignatures == nil {
+		field.invalidSignatures = map[string]bool{}
+	}
+
+	field.invalidSignatures[key] = true
+	if keyring == nil {
+		return ""
+	}
+
+	value, ok := openWithKeyring(keyring, signedFieldPurpose(inputName), submitted)
+	if !ok {
+		return ""
+	}
+
+	delete(field.invalidSignatures, key)
+	return string(value)
+}
+
+// signedFieldPurpose binds a signature to the field, so a signed value cannot
+// be copied into another signed field.
+func signedFieldPurpose(name string) string {
+	return "field\x00" + name
+}
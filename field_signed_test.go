@@ -0,0 +1,242 @@
+package form
+
+import (
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func testKeyring(t *testing.T, current string, ids ...string) Keyring {
+	t.Helper()
+
+	keys := map[string][]byte{}
+	for _, id := range ids {
+		keys[id] = []byte("secret key " + id + " of at least 32 bytes")
+	}
+
+	keyring, err := NewKeyring(current, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func signedTestForm(keyring Keyring) *Form {
+	return New().WithKeyring(keyring).WithFields(
+		NewHiddenField("order_id", "42").WithSigned(),
+		NewFieldRow(NewHiddenField("price", "19.99").WithSigned()),
+		NewStringField("note", "Note"),
+	)
+}
+
+// signedValues returns the values rendered in the hidden inputs of the form.
+func signedValues(t *testing.T, f *Form) url.Values {
+	t.Helper()
+
+	values := url.Values{}
+	page := f.Build().ToHTML()
+	for _, match := range regexp.MustCompile(`<input[^>]* name="(order_id|price)"[^>]* value="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+		values.Set(match[1], match[2])
+	}
+	if len(values) != 2 {
+		t.Fatal("Expected two signed inputs, got:", page)
+	}
+	return values
+}
+
+func TestNewKeyringErrors(t *testing.T) {
+	if _, err := NewKeyring("k1", map[string][]byte{"k2": []byte("key")}); err == nil {
+		t.Fatal("Expected an error for a missing current key")
+	}
+	if _, err := NewKeyring("k.1", map[string][]byte{"k.1": []byte("key")}); err == nil {
+		t.Fatal("Expected an error for a key ID with a dot")
+	}
+	if _, err := NewKeyring("k1", map[string][]byte{"k1": testCSRFKey, "k0": []byte("key")}); err == nil || err.Error() != "form: key k0 must be at least 32 bytes" {
+		t.Fatal("Expected an error for a short key, got:", err)
+	}
+}
+
+func TestSignedFieldRoundTrip(t *testing.T) {
+	keyring := testKeyring(t, "k1", "k1")
+	submitted := signedValues(t, signedTestForm(keyring))
+
+	if !strings.HasPrefix(submitted.Get("order_id"), "k1.") || strings.Contains(submitted.Get("order_id"), "42") {
+		t.Fatal("Expected a signed value, got:", submitted.Get("order_id"))
+	}
+
+	submitted.Set("note", "Leave at the door")
+	f := signedTestForm(keyring)
+	values := f.ParseValues(submitted)
+
+	if values["order_id"] != "42" || values["price"] != "19.99" || values["note"] != "Leave at the door" {
+		t.Fatal("Unexpected values:", values)
+	}
+	if errs := f.Validate(values); len(errs) != 0 {
+		t.Fatal("Expected no errors, got:", errs)
+	}
+}
+
+func TestSignedFieldTampered(t *testing.T) {
+	keyring := testKeyring(t, "k1", "k1")
+	signed := signedValues(t, signedTestForm(keyring))
+
+	tests := map[string]url.Values{
+		"plain value":    {"order_id": {"43"}, "price": signed["price"]},
+		"missing value":  {"price": signed["price"]},
+		"other field":    {"order_id": signed["price"], "price": signed["price"]},
+		"tampered value": {"order_id": {"k1." + tamper(strings.TrimPrefix(signed.Get("order_id"), "k1."))}, "price": signed["price"]},
+		"unknown key":    {"order_id": {"k9." + strings.TrimPrefix(signed.Get("order_id"), "k1.")}, "price": signed["price"]},
+	}
+
+	// A keyring with another secret under the same key ID
+	other, _ := NewKeyring("k1", map[string][]byte{"k1": []byte("another secret of at least 32 bytes")})
+	tests["other secret"] = url.Values{"order_id": signedValues(t, signedTestForm(other))["order_id"], "price": signed["price"]}
+
+	for name, submitted := range tests {
+		f := signedTestForm(keyring).WithLocale("de")
+		values := f.ParseValues(submitted)
+
+		if values["order_id"] != "" {
+			t.Errorf("%s: expected an empty value, got %q", name, values["order_id"])
+		}
+
+		errs := f.Validate(values)
+		if len(errs) != 1 || errs[0].Field != "order_id" || errs[0].Rule != RuleSignature ||
+			errs[0].Message != "order_id wurde verändert und kann nicht akzeptiert werden" {
+			t.Errorf("%s: expected a signature error, got %v", name, errs)
+		}
+	}
+}
+
+func TestSignedFieldKeyRotation(t *testing.T) {
+	signed := signedValues(t, signedTestForm(testKeyring(t, "k1", "k1")))
+
+	rotated := signedTestForm(testKeyring(t, "k2", "k1", "k2"))
+	if values := rotated.ParseValues(signed); values["order_id"] != "42" || len(rotated.Validate(values)) != 0 {
+		t.Fatal("Expected a value signed with the previous key to be accepted, got:", values)
+	}
+	if value := signedValues(t, rotated).Get("order_id"); !strings.HasPrefix(value, "k2.") {
+		t.Fatal("Expected new values to be signed with the current key, got:", value)
+	}
+
+	retired := signedTestForm(testKeyring(t, "k2", "k2"))
+	if values := retired.ParseValues(signed); values["order_id"] != "" || len(retired.Validate(values)) != 2 {
+		t.Fatal("Expected values signed with a retired key to be rejected, got:", values)
+	}
+}
+
+func TestSignedFieldDefinition(t *testing.T) {
+	data, err := signedTestForm(nil).MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"signed": true`) {
+		t.Fatal("Expected the signed flag in the definition, got:", string(data))
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field, ok := loaded.findField("price").(*Field); !ok || !field.Signed {
+		t.Fatal("Expected the loaded field to be signed")
+	}
+}
+
+func signedRepeaterTestForm(keyring Keyring, items []map[string]string) *Form {
+	return New().WithID("orderForm").WithKeyring(keyring).WithFields(
+		NewRepeater(RepeaterOptions{
+			Name: "items",
+			Fields: []FieldInterface{
+				NewHiddenField("id", "").WithSigned(),
+				NewStringField("quantity", "Quantity"),
+			},
+			Values:      items,
+			RepeaterUrl: "/repeater",
+		}),
+	)
+}
+
+// signedItemValues returns the values rendered in the id inputs of the repeater items.
+func signedItemValues(t *testing.T, page string) url.Values {
+	t.Helper()
+
+	values := url.Values{}
+	for _, match := range regexp.MustCompile(`<input[^>]* name="(items\[id\]\[\d+\])"[^>]* value="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+		values.Set(html.UnescapeString(match[1]), match[2])
+	}
+	return values
+}
+
+func TestSignedRepeaterItemFields(t *testing.T) {
+	keyring := testKeyring(t, "k1", "k1")
+	items := []map[string]string{{"id": "7"}, {"id": "8"}, {"id": "9"}}
+	signed := signedItemValues(t, signedRepeaterTestForm(keyring, items).Build().ToHTML())
+
+	if len(signed) != 3 || !strings.HasPrefix(signed.Get("items[id][0]"), "k1.") {
+		t.Fatal("Expected the item ids to be signed, got:", signed)
+	}
+
+	// Item 1 was removed in the browser, so the items are renumbered
+	submitted := url.Values{
+		"items[id][0]":       signed["items[id][0]"],
+		"items[id][2]":       signed["items[id][2]"],
+		"items[quantity][0]": {"1"},
+		"items[quantity][2]": {"3"},
+	}
+	f := signedRepeaterTestForm(keyring, nil)
+	values := f.ParseValues(submitted)
+	if values["items[id][0]"] != "7" || values["items[id][1]"] != "9" || values["items[quantity][1]"] != "3" {
+		t.Fatal("Unexpected values:", values)
+	}
+	if errs := f.Validate(values); len(errs) != 0 {
+		t.Fatal("Expected no errors, got:", errs)
+	}
+
+	tests := map[string]url.Values{
+		"plain value":    {"items[id][0]": {"8"}},
+		"other item":     {"items[id][0]": signed["items[id][1]"]},
+		"other field":    {"items[id][0]": signedValues(t, signedTestForm(keyring))["order_id"]},
+		"positional key": {"items[id][]": signed["items[id][1]"], "items[quantity][]": {"1"}},
+	}
+	for name, submitted := range tests {
+		f := signedRepeaterTestForm(keyring, nil)
+		values := f.ParseValues(submitted)
+		if values["items[id][0]"] != "" {
+			t.Errorf("%s: expected an empty value, got %q", name, values["items[id][0]"])
+		}
+
+		errs := f.Validate(values)
+		if len(errs) != 1 || errs[0].Field != "items[id][0]" || errs[0].Rule != RuleSignature {
+			t.Errorf("%s: expected a signature error, got %v", name, errs)
+		}
+	}
+}
+
+func TestSignedRepeaterItemFieldsController(t *testing.T) {
+	keyring := testKeyring(t, "k1", "k1")
+	items := []map[string]string{{"id": "7"}, {"id": "8"}}
+	submitted := signedItemValues(t, signedRepeaterTestForm(keyring, items).Build().ToHTML())
+
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm: func(r *http.Request) *Form {
+			return signedRepeaterTestForm(keyring, nil)
+		},
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/repeater?repeatable_move_up_index=1", strings.NewReader(submitted.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	controller.ServeHTTP(w, r)
+
+	moved := signedItemValues(t, w.Body.String())
+	values := signedRepeaterTestForm(keyring, nil).ParseValues(moved)
+	if values["items[id][0]"] != "8" || values["items[id][1]"] != "7" {
+		t.Fatal("Expected the moved items to be signed for their new inputs, got:", values)
+	}
+}
@@ -0,0 +1,192 @@
+package form
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts of the values submitted by date and datetime inputs.
+const dateLayout = "2006-01-02"
+const dateTimeLayout = "2006-01-02T15:04"
+const dateTimeSecondsLayout = "2006-01-02T15:04:05"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind fills the struct pointed to by dst from the form's current field values.
+// Struct fields are matched to form fields by their `form:"field_name"` tag;
+// fields without a tag, or tagged with "-", are left untouched.
+//
+// Supported struct field types are string, ints, uints, floats, bool,
+// time.Time (for date and datetime fields) and []string (for multiple selects).
+// Bool fields are true for the same values that check a checkbox: "1", "true", "on" and "yes".
+//
+// Values that cannot be converted are returned as ValidationErrors,
+// keyed by the name of the matching form field.
+func (form *Form) Bind(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("form: Bind requires a non-nil pointer to a struct")
+	}
+
+	var errs ValidationErrors
+	if err := form.bindStruct(rv.Elem(), &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (form *Form) bindStruct(rv reflect.Value, errs *ValidationErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		structField := rt.Field(i)
+		fv := rv.Field(i)
+
+		if structField.Anonymous && fv.Kind() == reflect.Struct {
+			if err := form.bindStruct(fv, errs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := tagName(structField.Tag.Get("form"))
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := form.findField(name)
+		if field == nil {
+			continue
+		}
+
+		verr, err := bindValue(fv, field)
+		if err != nil {
+			return err
+		}
+		if verr != nil {
+			form.localize(verr, field)
+			*errs = append(*errs, *verr)
+		}
+	}
+
+	return nil
+}
+
+// bindValue converts the value of the form field into the struct field.
+// A value that cannot be converted is reported as a validation error,
+// a struct field of an unsupported type as an error.
+func bindValue(fv reflect.Value, field FieldInterface) (*ValidationError, error) {
+	name := field.GetName()
+	value := field.GetValue()
+
+	if fv.Type() == timeType {
+		if value == "" {
+			fv.Set(reflect.Zero(timeType))
+			return nil, nil
+		}
+		t, err := parseFieldTime(field.GetType(), value)
+		if err != nil {
+			return ruleError(name, RuleDate, nil), nil
+		}
+		fv.Set(reflect.ValueOf(t))
+		return nil, nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		fv.SetBool(isTruthy(value))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value == "" {
+			fv.SetInt(0)
+			return nil, nil
+		}
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return ruleError(name, RuleInteger, nil), nil
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value == "" {
+			fv.SetUint(0)
+			return nil, nil
+		}
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return ruleError(name, RuleUnsignedInteger, nil), nil
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if value == "" {
+			fv.SetFloat(0)
+			return nil, nil
+		}
+		n, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return ruleError(name, RuleNumber, nil), nil
+		}
+		fv.SetFloat(n)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return nil, errors.New("form: cannot bind field " + name + " into " + fv.Type().String())
+		}
+		values := fieldValues(field)
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, value := range values {
+			slice.Index(i).SetString(value)
+		}
+		fv.Set(slice)
+	default:
+		return nil, errors.New("form: cannot bind field " + name + " into " + fv.Type().String())
+	}
+
+	return nil, nil
+}
+
+// fieldValues returns the values of a field as a slice. Multiple selects
+// return their selected values (or those of their value, if none are set),
+// other fields their single non-empty value.
+func fieldValues(field FieldInterface) []string {
+	if f, ok := field.(*Field); ok && f.Multiple {
+		if len(f.Values) == 0 && f.Value != "" {
+			return splitSelected(f.Value)
+		}
+		return append([]string{}, f.Values...)
+	}
+	if field.GetValue() == "" {
+		return []string{}
+	}
+	return []string{field.GetValue()}
+}
+
+// parseFieldTime parses a date or datetime value using the layout submitted
+// by the input of the given field type.
+func parseFieldTime(fieldType string, value string) (time.Time, error) {
+	if fieldType == FORM_FIELD_TYPE_DATETIME {
+		t, err := time.Parse(dateTimeLayout, value)
+		if err == nil {
+			return t, nil
+		}
+		return time.Parse(dateTimeSecondsLayout, value)
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// tagName returns the field name part of a `form` struct tag.
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return strings.TrimSpace(name)
+}
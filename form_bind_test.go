@@ -0,0 +1,177 @@
+package form
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type bindAddress struct {
+	City string `form:"city"`
+}
+
+type bindTarget struct {
+	bindAddress
+	Name     string    `form:"name"`
+	Quantity int       `form:"quantity"`
+	Price    float64   `form:"price"`
+	Agree    bool      `form:"agree"`
+	Birthday time.Time `form:"birthday"`
+	Meeting  time.Time `form:"meeting"`
+	Tags     []string  `form:"tags"`
+	Ignored  string    `form:"-"`
+	Untagged string
+}
+
+func newBindForm() *Form {
+	return New().WithFields(
+		NewStringField("name", "Name"),
+		NewFieldRow(
+			NewNumberField("quantity", "Quantity"),
+			NewNumberField("price", "Price"),
+		),
+		NewCheckboxField("agree", "Agree"),
+		NewDateField("birthday", "Birthday"),
+		NewDateTimeField("meeting", "Meeting"),
+		NewSelectField("tags", "Tags", []FieldOption{{Key: "a", Value: "A"}, {Key: "b", Value: "B"}}).WithMultiple(),
+		NewStringField("city", "City"),
+	)
+}
+
+func TestBind(t *testing.T) {
+	f := newBindForm()
+	f.ParseValues(url.Values{
+		"name":     {"Widget"},
+		"quantity": {"3"},
+		"price":    {"9.95"},
+		"agree":    {"on"},
+		"birthday": {"1990-05-17"},
+		"meeting":  {"2024-03-01T09:30"},
+		"tags":     {"a", "b"},
+		"city":     {"Sofia"},
+	})
+
+	dst := bindTarget{Ignored: "keep", Untagged: "keep"}
+	if err := f.Bind(&dst); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if dst.Name != "Widget" || dst.Quantity != 3 || dst.Price != 9.95 || !dst.Agree {
+		t.Fatal("Unexpected scalar values:", dst)
+	}
+	if !dst.Birthday.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Unexpected birthday:", dst.Birthday)
+	}
+	if !dst.Meeting.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Fatal("Unexpected meeting:", dst.Meeting)
+	}
+	if len(dst.Tags) != 2 || dst.Tags[0] != "a" || dst.Tags[1] != "b" {
+		t.Fatal("Unexpected tags:", dst.Tags)
+	}
+	if dst.City != "Sofia" {
+		t.Fatal("Expected embedded struct to be bound, got:", dst.City)
+	}
+	if dst.Ignored != "keep" || dst.Untagged != "keep" {
+		t.Fatal("Expected untagged fields to be untouched")
+	}
+}
+
+func TestBindConversionErrors(t *testing.T) {
+	f := newBindForm()
+	f.ParseValues(url.Values{
+		"quantity": {"three"},
+		"price":    {"cheap"},
+		"birthday": {"17/05/1990"},
+	})
+
+	var dst bindTarget
+	err := f.Bind(&dst)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatal("Expected ValidationErrors, got:", err)
+	}
+	if len(errs) != 3 {
+		t.Fatal("Expected 3 errors, got:", errs)
+	}
+
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, name := range []string{"quantity", "price", "birthday"} {
+		if !fields[name] {
+			t.Fatal("Expected error on", name, "got:", errs)
+		}
+	}
+}
+
+func TestBindFalseCheckbox(t *testing.T) {
+	f := newBindForm()
+	f.ParseValues(url.Values{})
+
+	dst := bindTarget{Agree: true}
+	if err := f.Bind(&dst); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if dst.Agree {
+		t.Fatal("Expected unchecked checkbox to bind false")
+	}
+}
+
+func TestBindRequiresStructPointer(t *testing.T) {
+	f := newBindForm()
+
+	var dst bindTarget
+	if err := f.Bind(dst); err == nil {
+		t.Fatal("Expected error for non-pointer")
+	}
+
+	var s string
+	if err := f.Bind(&s); err == nil {
+		t.Fatal("Expected error for pointer to non-struct")
+	}
+}
+
+func TestBindUnsupportedType(t *testing.T) {
+	f := New().WithFields(NewStringField("name", "Name").WithValue("x"))
+
+	dst := struct {
+		Name map[string]string `form:"name"`
+	}{}
+
+	err := f.Bind(&dst)
+	if err == nil {
+		t.Fatal("Expected error for unsupported type")
+	}
+
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		t.Fatal("Expected a plain error, got validation errors:", errs)
+	}
+}
+
+func TestBindNamedSliceType(t *testing.T) {
+	type Tag string
+
+	f := New().WithFields(NewSelectField("tags", "Tags", []FieldOption{{Key: "a", Value: "A"}, {Key: "b", Value: "B"}}).WithMultiple())
+	f.ParseValues(url.Values{"tags": {"a", "b"}})
+
+	dst := struct {
+		Tags []Tag `form:"tags"`
+	}{}
+	if err := f.Bind(&dst); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(dst.Tags) != 2 || dst.Tags[0] != "a" || dst.Tags[1] != "b" {
+		t.Fatal("Unexpected tags:", dst.Tags)
+	}
+
+	unsupported := struct {
+		Tags []int `form:"tags"`
+	}{}
+	if err := f.Bind(&unsupported); err == nil {
+		t.Fatal("Expected an error for an unsupported element type")
+	}
+}
@@ -0,0 +1,704 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/dracory/hb"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// definitionVersion is the version of the definition format written by
+// MarshalDefinition. LoadDefinition rejects other versions.
+const definitionVersion = 1
+
+// formDefinition is the stored form of a Form. Funcs, tags, themes and
+// message providers are stored by the names they are registered under.
+type formDefinition struct {
+	Version               int               `json:"version" yaml:"version"`
+	ID                    string            `json:"id,omitempty" yaml:"id,omitempty"`
+	ClassName             string            `json:"class_name,omitempty" yaml:"class_name,omitempty"`
+	Method                string            `json:"method,omitempty" yaml:"method,omitempty"`
+	Action                string            `json:"action,omitempty" yaml:"action,omitempty"`
+	FileManagerURL        string            `json:"file_manager_url,omitempty" yaml:"file_manager_url,omitempty"`
+	HxPost                string            `json:"hx_post,omitempty" yaml:"hx_post,omitempty"`
+	HxTarget              string            `json:"hx_target,omitempty" yaml:"hx_target,omitempty"`
+	HxSwap                string            `json:"hx_swap,omitempty" yaml:"hx_swap,omitempty"`
+	HTMX                  *htmxDefinition   `json:"htmx,omitempty" yaml:"htmx,omitempty"`
+	Theme                 string            `json:"theme,omitempty" yaml:"theme,omitempty"`
+	Locale                string            `json:"locale,omitempty" yaml:"locale,omitempty"`
+	Messages              string            `json:"messages,omitempty" yaml:"messages,omitempty"`
+	ErrorSummary          bool              `json:"error_summary,omitempty" yaml:"error_summary,omitempty"`
+	NoValidate            bool              `json:"no_validate,omitempty" yaml:"no_validate,omitempty"`
+	ValidationConcurrency int               `json:"validation_concurrency,omitempty" yaml:"validation_concurrency,omitempty"`
+	Fields                []fieldDefinition `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// htmxDefinition is the stored form of an HTMXConfig.
+type htmxDefinition struct {
+	Post        string `json:"post,omitempty" yaml:"post,omitempty"`
+	Get         string `json:"get,omitempty" yaml:"get,omitempty"`
+	Target      string `json:"target,omitempty" yaml:"target,omitempty"`
+	Swap        string `json:"swap,omitempty" yaml:"swap,omitempty"`
+	Trigger     string `json:"trigger,omitempty" yaml:"trigger,omitempty"`
+	Indicator   string `json:"indicator,omitempty" yaml:"indicator,omitempty"`
+	Confirm     string `json:"confirm,omitempty" yaml:"confirm,omitempty"`
+	Sync        string `json:"sync,omitempty" yaml:"sync,omitempty"`
+	Validate    bool   `json:"validate,omitempty" yaml:"validate,omitempty"`
+	DisabledElt string `json:"disabled_elt,omitempty" yaml:"disabled_elt,omitempty"`
+	Encoding    string `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	PushURL     string `json:"push_url,omitempty" yaml:"push_url,omitempty"`
+}
+
+// fieldDefinition is the stored form of a field. The type tells the kind of
+// field: "row" for field rows, "repeater" for repeaters, and the field type
+// for a *Field, e.g. "string" or "select".
+type fieldDefinition struct {
+	Type                string              `json:"type" yaml:"type"`
+	ID                  string              `json:"id,omitempty" yaml:"id,omitempty"`
+	Name                string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Label               string              `json:"label,omitempty" yaml:"label,omitempty"`
+	Help                string              `json:"help,omitempty" yaml:"help,omitempty"`
+	Value               string              `json:"value,omitempty" yaml:"value,omitempty"`
+	Placeholder         string              `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	Required            bool                `json:"required,omitempty" yaml:"required,omitempty"`
+	Readonly            bool                `json:"readonly,omitempty" yaml:"readonly,omitempty"`
+	Disabled            bool                `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Invisible           bool                `json:"invisible,omitempty" yaml:"invisible,omitempty"`
+	Multiple            bool                `json:"multiple,omitempty" yaml:"multiple,omitempty"`
+	Signed              bool                `json:"signed,omitempty" yaml:"signed,omitempty"`
+	Options             []optionDefinition  `json:"options,omitempty" yaml:"options,omitempty"`
+	OptionsFunc         string              `json:"options_func,omitempty" yaml:"options_func,omitempty"`
+	Values              []string            `json:"values,omitempty" yaml:"values,omitempty"`
+	Attrs               map[string]string   `json:"attrs,omitempty" yaml:"attrs,omitempty"`
+	Rules               []ruleDefinition    `json:"rules,omitempty" yaml:"rules,omitempty"`
+	ContextValidators   []string            `json:"context_validators,omitempty" yaml:"context_validators,omitempty"`
+	Sanitizers          []string            `json:"sanitizers,omitempty" yaml:"sanitizers,omitempty"`
+	CustomInput         string              `json:"custom_input,omitempty" yaml:"custom_input,omitempty"`
+	Table               *tableDefinition    `json:"table,omitempty" yaml:"table,omitempty"`
+	RowClass            string              `json:"row_class,omitempty" yaml:"row_class,omitempty"`
+	Columns             []columnDefinition  `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Fields              []fieldDefinition   `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Items               []map[string]string `json:"items,omitempty" yaml:"items,omitempty"`
+	RepeaterURL         string              `json:"repeater_url,omitempty" yaml:"repeater_url,omitempty"`
+	RepeaterAddURL      string              `json:"repeater_add_url,omitempty" yaml:"repeater_add_url,omitempty"`
+	RepeaterMoveUpURL   string              `json:"repeater_move_up_url,omitempty" yaml:"repeater_move_up_url,omitempty"`
+	RepeaterMoveDownURL string              `json:"repeater_move_down_url,omitempty" yaml:"repeater_move_down_url,omitempty"`
+	RepeaterRemoveURL   string              `json:"repeater_remove_url,omitempty" yaml:"repeater_remove_url,omitempty"`
+}
+
+// optionDefinition is the stored form of a FieldOption.
+type optionDefinition struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// ruleDefinition is the stored form of a Rule, recreated from its params
+// by the factory registered under its name.
+type ruleDefinition struct {
+	Name   string            `json:"name" yaml:"name"`
+	Params map[string]string `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// tableDefinition is the stored form of TableOptions.
+type tableDefinition struct {
+	Header          []columnHeaderDefinition `json:"header,omitempty" yaml:"header,omitempty"`
+	Rows            [][]fieldDefinition      `json:"rows,omitempty" yaml:"rows,omitempty"`
+	RowAddButton    string                   `json:"row_add_button,omitempty" yaml:"row_add_button,omitempty"`
+	RowDeleteButton string                   `json:"row_delete_button,omitempty" yaml:"row_delete_button,omitempty"`
+}
+
+// columnHeaderDefinition is the stored form of a TableColumn.
+type columnHeaderDefinition struct {
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+	Width int    `json:"width,omitempty" yaml:"width,omitempty"`
+}
+
+// columnDefinition is the stored form of a FieldRowColumn.
+type columnDefinition struct {
+	ColClass string          `json:"col_class,omitempty" yaml:"col_class,omitempty"`
+	Field    fieldDefinition `json:"field" yaml:"field"`
+}
+
+// MarshalDefinition returns the form's definition as JSON, which
+// LoadDefinition turns back into an equal form.
+//
+// Rules are stored by name and params, and must have a factory registered
+// with RegisterRule; the built-in rules have one, rules created from funcs
+// (Validator, ValidatorCustom, ValidatorCrossField) cannot be stored.
+// Options functions, context validators, custom inputs, table buttons, the
+// theme and the message provider are stored by the name they are registered
+// under with the Register* functions. Validation errors are not stored.
+func (form *Form) MarshalDefinition() ([]byte, error) {
+	definition, err := form.definition()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(definition, "", "  ")
+}
+
+// MarshalDefinitionYAML returns the form's definition as YAML.
+// See MarshalDefinition.
+func (form *Form) MarshalDefinitionYAML() ([]byte, error) {
+	definition, err := form.definition()
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(definition)
+}
+
+// LoadDefinition creates a form from a definition written by
+// MarshalDefinition or MarshalDefinitionYAML. Definitions starting with "{"
+// are read as JSON, others as YAML. Unknown keys, unknown field types,
+// unregistered names and unsupported versions are reported as errors.
+func LoadDefinition(data []byte) (*Form, error) {
+	definition := formDefinition{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, errors.New("form: invalid definition: " + err.Error())
+		}
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&definition); err != nil {
+			return nil, errors.New("form: invalid definition: " + err.Error())
+		}
+	}
+
+	if definition.Version != definitionVersion {
+		return nil, errors.New("form: unsupported definition version " + strconv.Itoa(definition.Version))
+	}
+
+	form, err := definition.form()
+	if err != nil {
+		return nil, errors.New("form: invalid definition: " + err.Error())
+	}
+
+	return form, nil
+}
+
+// == MARSHAL =================================================================
+
+// definition returns the stored form of the form.
+func (form *Form) definition() (*formDefinition, error) {
+	definition := &formDefinition{
+		Version:               definitionVersion,
+		ID:                    form.id,
+		ClassName:             form.className,
+		Method:                form.method,
+		Action:                form.actionUrl,
+		FileManagerURL:        form.fileManagerURL,
+		HxPost:                form.hxPost,
+		HxTarget:              form.hxTarget,
+		HxSwap:                form.hxSwap,
+		Locale:                form.locale,
+		ErrorSummary:          form.errorSummary,
+		NoValidate:            form.noValidate,
+		ValidationConcurrency: form.validationConcurrency,
+	}
+
+	if form.htmxConfig != nil {
+		htmx := htmxDefinition(*form.htmxConfig)
+		definition.HTMX = &htmx
+	}
+
+	if form.theme != nil {
+		name, err := registryName(registry, registry.themes, "theme", form.theme, sameTheme)
+		if err != nil {
+			return nil, errors.New("form: " + err.Error())
+		}
+		definition.Theme = name
+	}
+
+	if form.messages != nil {
+		name, err := registryName(registry, registry.messages, "message provider", form.messages, sameMessages)
+		if err != nil {
+			return nil, errors.New("form: " + err.Error())
+		}
+		definition.Messages = name
+	}
+
+	fields, err := fieldDefinitions(form.fields)
+	if err != nil {
+		return nil, err
+	}
+	definition.Fields = fields
+
+	return definition, nil
+}
+
+// fieldDefinitions returns the stored form of the fields.
+func fieldDefinitions(fields []FieldInterface) ([]fieldDefinition, error) {
+	definitions := []fieldDefinition{}
+
+	for _, field := range fields {
+		definition, err := fieldDefinitionOf(field)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
+// fieldDefinitionOf returns the stored form of a field.
+func fieldDefinitionOf(field FieldInterface) (fieldDefinition, error) {
+	switch f := field.(type) {
+	case *Field:
+		return f.definition()
+	case *fieldRow:
+		return f.definition()
+	case *fieldRepeater:
+		return f.definition()
+	}
+
+	return fieldDefinition{}, errors.New("form: field " + field.GetName() + " of type " + field.GetType() + " cannot be stored in a definition")
+}
+
+// definition returns the stored form of the field.
+func (field *Field) definition() (fieldDefinition, error) {
+	definition := fieldDefinition{
+		Type:        field.Type,
+		ID:          field.ID,
+		Name:        field.Name,
+		Label:       field.Label,
+		Help:        field.Help,
+		Value:       field.Value,
+		Placeholder: field.Placeholder,
+		Required:    field.Required,
+		Readonly:    field.Readonly,
+		Disabled:    field.Disabled,
+		Invisible:   field.Invisible,
+		Multiple:    field.Multiple,
+		Signed:      field.Signed,
+		Values:      field.Values,
+		Attrs:       field.Attrs,
+	}
+
+	fail := func(err error) (fieldDefinition, error) {
+		return fieldDefinition{}, errors.New("form: field " + field.Name + ": " + err.Error())
+	}
+
+	for _, option := range field.Options {
+		definition.Options = append(definition.Options, optionDefinition(option))
+	}
+
+	if field.OptionsF != nil {
+		name, ok := optionsFuncName(field)
+		if !ok {
+			return fail(errors.New("options function is not registered, set it with WithOptionsFunc"))
+		}
+		definition.OptionsFunc = name
+	}
+
+	for _, rule := range field.Validators {
+		if !registry.hasRule(rule.Name()) {
+			return fail(errors.New("rule " + rule.Name() + " is not registered"))
+		}
+		definition.Rules = append(definition.Rules, ruleDefinition{
+			Name:   rule.Name(),
+			Params: rule.Params(),
+		})
+	}
+
+	for _, validator := range field.ContextValidators {
+		name, ok := contextValidatorName(validator)
+		if !ok {
+			return fail(errors.New("context validator is not registered, use the one RegisterContextValidator returns"))
+		}
+		definition.ContextValidators = append(definition.ContextValidators, name)
+	}
+
+	for _, sanitizer := range field.Sanitizers {
+		name, ok := sanitizerName(sanitizer)
+		if !ok {
+			return fail(errors.New("sanitizer is not registered, use the one RegisterSanitizer returns"))
+		}
+		definition.Sanitizers = append(definition.Sanitizers, name)
+	}
+
+	if field.CustomInput != nil {
+		name, err := registeredTagName(field.CustomInput)
+		if err != nil {
+			return fail(err)
+		}
+		definition.CustomInput = name
+	}
+
+	table, err := field.TableOptions.definition()
+	if err != nil {
+		return fail(err)
+	}
+	definition.Table = table
+
+	return definition, nil
+}
+
+// definition returns the stored form of the table options, nil when empty.
+func (options TableOptions) definition() (*tableDefinition, error) {
+	if len(options.Header) == 0 && len(options.Rows) == 0 && options.RowAddButton == nil && options.RowDeleteButton == nil {
+		return nil, nil
+	}
+
+	definition := &tableDefinition{}
+
+	for _, column := range options.Header {
+		definition.Header = append(definition.Header, columnHeaderDefinition(column))
+	}
+
+	for _, row := range options.Rows {
+		rowDefinition := []fieldDefinition{}
+		for i := range row {
+			fieldDefinition, err := row[i].definition()
+			if err != nil {
+				return nil, err
+			}
+			rowDefinition = append(rowDefinition, fieldDefinition)
+		}
+		definition.Rows = append(definition.Rows, rowDefinition)
+	}
+
+	if options.RowAddButton != nil {
+		name, err := registeredTagName(options.RowAddButton)
+		if err != nil {
+			return nil, err
+		}
+		definition.RowAddButton = name
+	}
+
+	if options.RowDeleteButton != nil {
+		name, err := registeredTagName(options.RowDeleteButton)
+		if err != nil {
+			return nil, err
+		}
+		definition.RowDeleteButton = name
+	}
+
+	return definition, nil
+}
+
+// definition returns the stored form of the row.
+func (r *fieldRow) definition() (fieldDefinition, error) {
+	definition := fieldDefinition{
+		Type:     "row",
+		RowClass: r.rowClass,
+	}
+
+	for _, column := range r.columns {
+		field, err := fieldDefinitionOf(column.Field)
+		if err != nil {
+			return fieldDefinition{}, err
+		}
+		definition.Columns = append(definition.Columns, columnDefinition{
+			ColClass: column.ColClass,
+			Field:    field,
+		})
+	}
+
+	return definition, nil
+}
+
+// definition returns the stored form of the repeater.
+func (field *fieldRepeater) definition() (fieldDefinition, error) {
+	fields, err := fieldDefinitions(field.fields)
+	if err != nil {
+		return fieldDefinition{}, err
+	}
+
+	return fieldDefinition{
+		Type:                formFieldTypeRepeater,
+		ID:                  field.fieldID,
+		Name:                field.fieldName,
+		Label:               field.fieldLabel,
+		Help:                field.fieldHelp,
+		Value:               field.fieldValue,
+		Fields:              fields,
+		Items:               field.values,
+		RepeaterURL:         field.repeaterUrl,
+		RepeaterAddURL:      field.repeaterAddUrl,
+		RepeaterMoveUpURL:   field.repeaterMoveUpUrl,
+		RepeaterMoveDownURL: field.repeaterMoveDownUrl,
+		RepeaterRemoveURL:   field.repeaterRemoveUrl,
+	}, nil
+}
+
+// registeredTagName returns the name the tag is registered under.
+func registeredTagName(tag hb.TagInterface) (string, error) {
+	return registryName(registry, registry.tags, "tag", tag, func(a hb.TagInterface, b hb.TagInterface) bool {
+		return sameValue(a, b)
+	})
+}
+
+// sameTheme reports whether two themes have the same classes.
+func sameTheme(a *Theme, b *Theme) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// sameMessages reports whether two message providers are the same.
+func sameMessages(a MessageProvider, b MessageProvider) bool {
+	return sameValue(a, b)
+}
+
+// == LOAD ====================================================================
+
+// form returns the form stored by the definition.
+func (definition *formDefinition) form() (*Form, error) {
+	form := &Form{
+		id:                    definition.ID,
+		className:             definition.ClassName,
+		method:                definition.Method,
+		actionUrl:             definition.Action,
+		fileManagerURL:        definition.FileManagerURL,
+		hxPost:                definition.HxPost,
+		hxTarget:              definition.HxTarget,
+		hxSwap:                definition.HxSwap,
+		locale:                definition.Locale,
+		errorSummary:          definition.ErrorSummary,
+		noValidate:            definition.NoValidate,
+		validationConcurrency: definition.ValidationConcurrency,
+	}
+
+	if definition.HTMX != nil {
+		htmx := HTMXConfig(*definition.HTMX)
+		form.htmxConfig = &htmx
+	}
+
+	if definition.Theme != "" {
+		theme, err := registryLookup(registry, registry.themes, "theme", definition.Theme)
+		if err != nil {
+			return nil, err
+		}
+		form.theme = theme
+	}
+
+	if definition.Messages != "" {
+		messages, err := registryLookup(registry, registry.messages, "message provider", definition.Messages)
+		if err != nil {
+			return nil, err
+		}
+		form.messages = messages
+	}
+
+	fields, err := loadFields(definition.Fields)
+	if err != nil {
+		return nil, err
+	}
+	form.fields = fields
+
+	return form, nil
+}
+
+// loadFields returns the fields stored by the definitions.
+func loadFields(definitions []fieldDefinition) ([]FieldInterface, error) {
+	fields := []FieldInterface{}
+
+	for _, definition := range definitions {
+		field, err := definition.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// field returns the field stored by the definition.
+func (definition fieldDefinition) field() (FieldInterface, error) {
+	switch definition.Type {
+	case "row":
+		return definition.row()
+	case formFieldTypeRepeater:
+		return definition.repeater()
+	}
+
+	return definition.plainField()
+}
+
+// plainField returns the *Field stored by the definition.
+func (definition fieldDefinition) plainField() (*Field, error) {
+	if !lo.Contains(fieldTypes, definition.Type) && definition.Type != FORM_FIELD_TYPE_TABLE && definition.Type != FORM_FIELD_TYPE_RAW {
+		return nil, errors.New("field " + definition.Name + ": unknown field type \"" + definition.Type + "\"")
+	}
+
+	field := &Field{
+		ID:          definition.ID,
+		Type:        definition.Type,
+		Name:        definition.Name,
+		Label:       definition.Label,
+		Help:        definition.Help,
+		Value:       definition.Value,
+		Placeholder: definition.Placeholder,
+		Required:    definition.Required,
+		Readonly:    definition.Readonly,
+		Disabled:    definition.Disabled,
+		Invisible:   definition.Invisible,
+		Multiple:    definition.Multiple,
+		Signed:      definition.Signed,
+		Values:      definition.Values,
+		Attrs:       definition.Attrs,
+	}
+
+	fail := func(err error) (*Field, error) {
+		return nil, errors.New("field " + definition.Name + ": " + err.Error())
+	}
+
+	for _, option := range definition.Options {
+		field.Options = append(field.Options, FieldOption(option))
+	}
+
+	if definition.OptionsFunc != "" {
+		if _, err := registryLookup(registry, registry.optionsFuncs, "options function", definition.OptionsFunc); err != nil {
+			return fail(err)
+		}
+		field.WithOptionsFunc(definition.OptionsFunc)
+	}
+
+	for _, ruleDefinition := range definition.Rules {
+		rule, err := registry.rule(ruleDefinition.Name, ruleDefinition.Params)
+		if err != nil {
+			return fail(err)
+		}
+		field.Validators = append(field.Validators, rule)
+	}
+
+	for _, name := range definition.ContextValidators {
+		validator, err := registryLookup(registry, registry.contextValidators, "context validator", name)
+		if err != nil {
+			return fail(err)
+		}
+		field.ContextValidators = append(field.ContextValidators, validator)
+	}
+
+	for _, name := range definition.Sanitizers {
+		sanitizer, err := registryLookup(registry, registry.sanitizers, "sanitizer", name)
+		if err != nil {
+			return fail(err)
+		}
+		field.Sanitizers = append(field.Sanitizers, sanitizer)
+	}
+
+	if definition.CustomInput != "" {
+		tag, err := registryLookup(registry, registry.tags, "tag", definition.CustomInput)
+		if err != nil {
+			return fail(err)
+		}
+		field.CustomInput = tag
+	}
+
+	if definition.Table != nil {
+		options, err := definition.Table.options()
+		if err != nil {
+			return fail(err)
+		}
+		field.TableOptions = options
+	}
+
+	return field, nil
+}
+
+// options returns the table options stored by the definition.
+func (definition *tableDefinition) options() (TableOptions, error) {
+	options := TableOptions{}
+
+	for _, column := range definition.Header {
+		options.Header = append(options.Header, TableColumn(column))
+	}
+
+	for _, rowDefinition := range definition.Rows {
+		row := []Field{}
+		for _, fieldDefinition := range rowDefinition {
+			field, err := fieldDefinition.plainField()
+			if err != nil {
+				return TableOptions{}, err
+			}
+			row = append(row, *field)
+		}
+		options.Rows = append(options.Rows, row)
+	}
+
+	if definition.RowAddButton != "" {
+		button, err := buttonTag(definition.RowAddButton)
+		if err != nil {
+			return TableOptions{}, err
+		}
+		options.RowAddButton = button
+	}
+
+	if definition.RowDeleteButton != "" {
+		button, err := buttonTag(definition.RowDeleteButton)
+		if err != nil {
+			return TableOptions{}, err
+		}
+		options.RowDeleteButton = button
+	}
+
+	return options, nil
+}
+
+// buttonTag returns the *hb.Tag registered under the name.
+func buttonTag(name string) (*hb.Tag, error) {
+	tag, err := registryLookup(registry, registry.tags, "tag", name)
+	if err != nil {
+		return nil, err
+	}
+
+	button, ok := tag.(*hb.Tag)
+	if !ok {
+		return nil, errors.New("tag " + name + " is not an *hb.Tag")
+	}
+
+	return button, nil
+}
+
+// row returns the field row stored by the definition.
+func (definition fieldDefinition) row() (*fieldRow, error) {
+	columns := []FieldRowColumn{}
+
+	for _, column := range definition.Columns {
+		field, err := column.Field.field()
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, FieldRowColumn{
+			Field:    field,
+			ColClass: column.ColClass,
+		})
+	}
+
+	return NewFieldRowWithColumns(columns...).WithRowClass(definition.RowClass), nil
+}
+
+// repeater returns the repeater stored by the definition.
+func (definition fieldDefinition) repeater() (*fieldRepeater, error) {
+	fields, err := loadFields(definition.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	repeater := NewRepeater(RepeaterOptions{
+		Label:               definition.Label,
+		Name:                definition.Name,
+		Value:               definition.Value,
+		Help:                definition.Help,
+		Fields:              fields,
+		Values:              definition.Items,
+		RepeaterUrl:         definition.RepeaterURL,
+		RepeaterAddUrl:      definition.RepeaterAddURL,
+		RepeaterMoveUpUrl:   definition.RepeaterMoveUpURL,
+		RepeaterMoveDownUrl: definition.RepeaterMoveDownURL,
+		RepeaterRemoveUrl:   definition.RepeaterRemoveURL,
+	})
+	repeater.SetID(definition.ID)
+
+	return repeater, nil
+}
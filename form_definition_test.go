@@ -0,0 +1,383 @@
+package form
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dracory/hb"
+)
+
+func definitionTestForm() *Form {
+	countries := func() []FieldOption {
+		return []FieldOption{{Key: "de", Value: "Germany"}}
+	}
+	RegisterOptionsFunc("test_countries", countries)
+
+	unique := RegisterContextValidator("test_unique", func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+		return nil, nil
+	})
+
+	editor := hb.Div().Class("editor")
+	RegisterTag("test_editor", editor)
+
+	addButton := hb.Button().Text("Add")
+	RegisterTag("test_add_button", addButton)
+
+	catalog := MessageCatalog{"en": {RuleRequired: "Please fill in {field}"}}
+	RegisterMessages("test_messages", catalog)
+
+	fields := []FieldInterface{
+		NewStringField("name", "Name").
+			WithID("name_id").
+			WithHelp("Your full name").
+			WithPlaceholder("Jane").
+			WithRequired().
+			WithAttr("autocomplete", "name").
+			WithValidators(ValidatorMinLength(2), ValidatorMaxLength(50), ValidatorPattern(`^[A-Za-z ]+$`, "Letters only")).
+			WithContextValidators(unique),
+		NewEmailField("email", "Email").WithValidators(ValidatorEmail()),
+		NewNumberField("age", "Age").WithValidators(ValidatorMin(18), ValidatorMax(120.5)),
+		NewPasswordField("password", "Password"),
+		NewStringField("password_confirm", "Confirm").WithValidators(ValidatorSameAs("password")),
+		NewHiddenField("token", "abc"),
+		NewDateField("birthday", "Birthday"),
+		NewDateTimeField("meeting", "Meeting"),
+		NewSelectField("size", "Size", []FieldOption{{Key: "s", Value: "Small"}, {Key: "m", Value: "Medium"}}).
+			WithMultiple().
+			WithValidators(ValidatorOneOf("s", "m")),
+		NewSelectField("country", "Country", nil).WithOptionsFunc("test_countries"),
+		NewTextAreaField("bio", "Bio").WithReadonly(),
+		NewCheckboxField("agree", "Agree").WithDisabled(),
+		NewRadioField("color", "Color", []FieldOption{{Key: "red", Value: "Red"}}),
+		NewFileField("cv", "CV"),
+		NewImageField("avatar", "Avatar"),
+		NewColorField("favorite", "Favorite"),
+		NewTelField("phone", "Phone").WithValidators(ValidatorRequiredIf("contact", "phone", "sms")),
+		NewURLField("website", "Website").WithValidators(ValidatorURL()).WithInvisible(),
+		NewHtmlAreaField("content", "Content").WithCustomInput(editor),
+		NewRawField("<hr>"),
+		NewFieldRowWithColumns(
+			FieldRowColumn{Field: NewStringField("first", "First"), ColClass: "col-md-6"},
+			FieldRowColumn{Field: NewStringField("last", "Last")},
+		).WithRowClass("row g-3"),
+		NewRepeater(RepeaterOptions{
+			Label:       "Addresses",
+			Name:        "addresses",
+			Help:        "Where you live",
+			Fields:      []FieldInterface{NewStringField("city", "City").WithValidators(ValidatorRequired())},
+			Values:      []map[string]string{{"city": "Berlin"}, {"city": "Madrid"}},
+			RepeaterUrl: "/repeater",
+		}),
+		NewField(FieldOptions{
+			Type:  FORM_FIELD_TYPE_TABLE,
+			Name:  "prices",
+			Label: "Prices",
+			TableOptions: TableOptions{
+				Header:       []TableColumn{{Label: "Item", Width: 60}, {Label: "Price"}},
+				Rows:         [][]Field{{*NewStringField("item", "Item").WithID("item"), *NewNumberField("price", "Price").WithID("price").WithValidators(ValidatorMin(0))}},
+				RowAddButton: addButton,
+			},
+		}),
+	}
+
+	return New().
+		WithID("profile").
+		WithClass("form").
+		WithAction("/profile").
+		WithFileManager("/files").
+		WithHxPost("/profile").
+		WithHxTarget("#profile").
+		WithHxSwap("outerHTML").
+		WithHTMX(HTMXConfig{Post: "/profile", Trigger: "submit", Validate: true}).
+		WithTheme(ThemeTailwind()).
+		WithLocale("de").
+		WithMessages(catalog).
+		WithErrorSummary(true).
+		WithNoValidate(true).
+		WithValidationConcurrency(4).
+		WithFields(fields...)
+}
+
+func TestMarshalDefinitionRoundTrip(t *testing.T) {
+	f := definitionTestForm()
+	html := f.Build().ToHTML() // assigns the automatic field IDs, which are stored too
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			marshal := func(f *Form) []byte {
+				var data []byte
+				var err error
+				if format == "json" {
+					data, err = f.MarshalDefinition()
+				} else {
+					data, err = f.MarshalDefinitionYAML()
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				return data
+			}
+
+			data := marshal(f)
+
+			loaded, err := LoadDefinition(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if again := marshal(loaded); string(again) != string(data) {
+				t.Fatalf("Expected the loaded form to marshal the same, got:\n%s\nwant:\n%s", again, data)
+			}
+
+			if loaded.Build().ToHTML() != html {
+				t.Fatal("Expected the loaded form to render the same HTML")
+			}
+		})
+	}
+}
+
+func TestLoadDefinitionRestoresFields(t *testing.T) {
+	data, err := definitionTestForm().MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *f.theme != *ThemeTailwind() {
+		t.Fatal("Expected the Tailwind theme")
+	}
+
+	if f.locale != "de" || f.method != "POST" || !f.errorSummary || !f.noValidate || f.validationConcurrency != 4 {
+		t.Fatal("Unexpected form settings:", f)
+	}
+
+	if f.htmxConfig == nil || f.htmxConfig.Trigger != "submit" || !f.htmxConfig.Validate {
+		t.Fatal("Unexpected HTMX config:", f.htmxConfig)
+	}
+
+	name := f.findField("name").(*Field)
+	if name.ID != "name_id" || name.Placeholder != "Jane" || !name.Required || name.Attrs["autocomplete"] != "name" {
+		t.Fatal("Unexpected name field:", name)
+	}
+	assertRuleNames(t, name, RuleMinLength, RuleMaxLength, RulePattern)
+	if !reflect.DeepEqual(name.Validators[2].Params(), map[string]string{"pattern": `^[A-Za-z ]+$`, "message": "Letters only"}) {
+		t.Fatal("Unexpected pattern params:", name.Validators[2].Params())
+	}
+	if len(name.ContextValidators) != 1 {
+		t.Fatal("Expected the context validator to be restored")
+	}
+
+	if err := name.Validators[0].Validate("name", "J", nil); err == nil {
+		t.Fatal("Expected the restored min_length rule to validate")
+	}
+
+	country := f.findField("country").(*Field)
+	if country.OptionsF == nil || country.OptionsF()[0].Key != "de" {
+		t.Fatal("Expected the options function to be restored")
+	}
+
+	phone := f.findField("phone").(*Field)
+	if !reflect.DeepEqual(phone.Validators[0].Params(), map[string]string{"other": "contact", "values": `["phone","sms"]`}) {
+		t.Fatal("Unexpected required_if params:", phone.Validators[0].Params())
+	}
+
+	prices := f.findField("prices").(*Field)
+	if len(prices.TableOptions.Rows) != 1 || prices.TableOptions.Rows[0][1].Name != "price" || prices.TableOptions.RowAddButton == nil {
+		t.Fatal("Unexpected table options:", prices.TableOptions)
+	}
+
+	repeater := f.findField("addresses").(*fieldRepeater)
+	if len(repeater.values) != 2 || repeater.values[1]["city"] != "Madrid" || repeater.repeaterUrl != "/repeater" {
+		t.Fatal("Unexpected repeater:", repeater)
+	}
+}
+
+func TestLoadDefinitionYAML(t *testing.T) {
+	definition := `
+version: 1
+id: contact
+theme: bootstrap5
+fields:
+  - type: string
+    name: name
+    label: Name
+    required: true
+    rules:
+      - name: max_length
+        params: {max: "20"}
+  - type: row
+    columns:
+      - col_class: col-6
+        field: {type: email, name: email}
+`
+	f, err := LoadDefinition([]byte(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := f.Validate(map[string]string{"name": strings.Repeat("a", 21), "email": "x@example.com"})
+	if len(errs) != 1 || errs[0].Rule != RuleMaxLength {
+		t.Fatal("Expected a max_length error, got:", errs)
+	}
+
+	if f.findField("email") == nil {
+		t.Fatal("Expected the email field inside the row")
+	}
+}
+
+func TestMarshalDefinitionErrors(t *testing.T) {
+	tests := map[string]*Form{
+		"rule custom is not registered": New().WithFields(
+			NewStringField("name", "Name").WithValidators(ValidatorCustom(func(string) string { return "" })),
+		),
+		"options function is not registered": New().WithFields(
+			NewSelectField("size", "Size", nil).WithOptionsF(func() []FieldOption { return nil }),
+		),
+		"tag is not registered": New().WithFields(
+			NewStringField("name", "Name").WithCustomInput(hb.Div()),
+		),
+		"theme is not registered": New().WithTheme(&Theme{FormGroupClass: "unknown"}),
+	}
+
+	for expected, f := range tests {
+		if _, err := f.MarshalDefinition(); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q, got: %v", expected, err)
+		}
+	}
+}
+
+func TestMarshalDefinitionCustomRule(t *testing.T) {
+	postcode := func(params map[string]string) (Rule, error) {
+		length := len(params["country"])
+		return NewRule("test_postcode", params, func(fieldName string, value string, values map[string]string) *ValidationError {
+			if len(value) < length {
+				return &ValidationError{Field: fieldName, Message: "Invalid postcode"}
+			}
+			return nil
+		}), nil
+	}
+	RegisterRule("test_postcode", postcode)
+
+	rule, _ := postcode(map[string]string{"country": "de"})
+	f := New().WithFields(NewStringField("postcode", "Postcode").WithValidators(rule))
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := loaded.Validate(map[string]string{"postcode": "1"})
+	if len(errs) != 1 || errs[0].Message != "Invalid postcode" {
+		t.Fatal("Expected the restored custom rule to validate, got:", errs)
+	}
+}
+
+func TestLoadDefinitionErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"version": 2}`: "unsupported definition version 2",
+		`{"version": 1, "fields": [{"type": "string", "name": "a", "unknown": true}]}`:                                            "unknown field",
+		`{"version": 1, "theme": "missing"}`:                                                                                      "theme missing is not registered",
+		`{"version": 1, "fields": [{"type": "string", "name": "a", "rules": [{"name": "missing"}]}]}`:                             "rule missing is not registered",
+		`{"version": 1, "fields": [{"type": "string", "name": "a", "rules": [{"name": "min_length"}]}]}`:                          `rule min_length: invalid min ""`,
+		`{"version": 1, "fields": [{"type": "string", "name": "a", "options_func": "missing"}]}`:                                  "options function missing is not registered",
+		"version: 1\nfields:\n  - type: string\n    unknown: true\n":                                                              "field unknown not found",
+		`{"version": 1, "fields": [{"type": "string", "name": "a", "rules": [{"name": "pattern", "params": {"pattern": "("}}]}]}`: "invalid pattern",
+		`{"version": 1, "fields": [{"type": "bogus", "name": "a"}]}`:                                                              `field a: unknown field type "bogus"`,
+		`{"version": 1, "fields": [{"type": "row", "columns": [{"field": {"name": "b"}}]}]}`:                                      `field b: unknown field type ""`,
+	}
+
+	for definition, expected := range tests {
+		if _, err := LoadDefinition([]byte(definition)); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q for %s, got: %v", expected, definition, err)
+		}
+	}
+}
+
+func TestRegistryNameAmbiguous(t *testing.T) {
+	tag := hb.Div().Class("ambiguous")
+	RegisterTag("test_ambiguous_a", tag)
+	RegisterTag("test_ambiguous_b", tag)
+
+	f := New().WithFields(NewStringField("name", "Name").WithCustomInput(tag))
+
+	_, err := f.MarshalDefinition()
+	if err == nil || !strings.Contains(err.Error(), "test_ambiguous_a, test_ambiguous_b") {
+		t.Fatal("Expected an ambiguous name error, got:", err)
+	}
+}
+
+func TestRegisteredFuncsCarryTheirName(t *testing.T) {
+	uniqueIn := func(table string) ContextValidator {
+		return func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+			if value == table {
+				return &ValidationError{Field: fieldName, Message: "taken"}, nil
+			}
+			return nil, nil
+		}
+	}
+
+	users := RegisterContextValidator("test_unique_users", uniqueIn("users"))
+	products := RegisterContextValidator("test_unique_products", uniqueIn("products"))
+
+	f := New().WithFields(NewStringField("email", "Email").WithContextValidators(uniqueIn("products")))
+	if _, err := f.MarshalDefinition(); err == nil || !strings.Contains(err.Error(), "context validator is not registered") {
+		t.Fatal("Expected an unregistered closure not to be stored, got:", err)
+	}
+
+	f = New().WithFields(NewStringField("email", "Email").WithContextValidators(users, products))
+	data, err := f.MarshalDefinition()
+	if err != nil || !strings.Contains(string(data), `"test_unique_users",`) || !strings.Contains(string(data), `"test_unique_products"`) {
+		t.Fatal("Expected the registered closures to be stored by name, got:", string(data), err)
+	}
+
+	if result, err := products(context.Background(), "email", "products", nil); err != nil || result == nil {
+		t.Fatal("Expected the registered closure to validate, got:", result, err)
+	}
+
+	lower := RegisterSanitizer("test_lower", strings.ToLower)
+	f = New().WithFields(NewStringField("name", "Name").WithSanitizers(lower, SanitizerTrim()))
+	data, err = f.MarshalDefinition()
+	if err != nil || !strings.Contains(string(data), `"test_lower",`) || !strings.Contains(string(data), `"trim"`) {
+		t.Fatal("Expected the sanitizers to be stored by name, got:", string(data), err)
+	}
+
+	if lower("ABC") != "abc" {
+		t.Fatal("Expected the registered sanitizer to sanitize, got:", lower("ABC"))
+	}
+}
+
+func TestWithOptionsFunc(t *testing.T) {
+	RegisterOptionsFunc("test_sizes", func() []FieldOption {
+		return []FieldOption{{Key: "s", Value: "Small"}}
+	})
+
+	field := NewSelectField("size", "Size", nil).WithOptionsFunc("test_sizes")
+	if options := field.OptionsF(); len(options) != 1 || options[0].Key != "s" {
+		t.Fatal("Expected the registered options, got:", options)
+	}
+
+	if options := NewSelectField("size", "Size", nil).WithOptionsFunc("test_missing").OptionsF(); options != nil {
+		t.Fatal("Expected no options for an unregistered name, got:", options)
+	}
+
+	data, err := New().WithFields(field).MarshalDefinition()
+	if err != nil || !strings.Contains(string(data), `"options_func": "test_sizes"`) {
+		t.Fatal("Expected the options function by name, got:", string(data), err)
+	}
+
+	field.WithOptionsF(field.OptionsF)
+	if _, err := New().WithFields(field).MarshalDefinition(); err == nil || !strings.Contains(err.Error(), "options function is not registered") {
+		t.Fatal("Expected an options function set with WithOptionsF not to be stored, got:", err)
+	}
+}
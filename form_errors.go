@@ -0,0 +1,162 @@
+package form
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dracory/hb"
+)
+
+// singleErrors converts a map of one error message per field
+// to a map of error messages per field.
+func singleErrors(errors map[string]string) map[string][]string {
+	if errors == nil {
+		return nil
+	}
+	converted := make(map[string][]string, len(errors))
+	for name, message := range errors {
+		converted[name] = []string{message}
+	}
+	return converted
+}
+
+// buildErrorMessages renders a field's error messages: a single message
+// as is, several messages as a list.
+func buildErrorMessages(theme *Theme, messages []string) *hb.Tag {
+	errorDiv := hb.NewDiv().Class(theme.ErrorClass)
+
+	if len(messages) == 1 {
+		return errorDiv.HTML(messages[0])
+	}
+
+	list := hb.NewUL()
+	if theme.ErrorListClass != "" {
+		list.Class(theme.ErrorListClass)
+	}
+	for _, message := range messages {
+		list.Child(hb.NewLI().HTML(message))
+	}
+
+	return errorDiv.Child(list)
+}
+
+// buildErrorSummary renders the error summary listing the form errors and,
+// when enabled with WithErrorSummary, the field errors linked to their inputs.
+// It returns nil when there is nothing to list.
+func (form *Form) buildErrorSummary(theme *Theme) *hb.Tag {
+	list := hb.NewUL()
+	if theme.ErrorSummaryListClass != "" {
+		list.Class(theme.ErrorSummaryListClass)
+	}
+
+	count := 0
+
+	for _, message := range form.formErrors {
+		list.Child(hb.NewLI().HTML(message))
+		count++
+	}
+
+	if form.errorSummary {
+		for _, key := range form.errorKeys() {
+			for _, message := range form.errors[key] {
+				item := hb.NewLI()
+				if id := form.inputID(key); id != "" {
+					item.Child(hb.NewHyperlink().Href("#" + id).HTML(message))
+				} else {
+					item.HTML(message)
+				}
+				list.Child(item)
+				count++
+			}
+		}
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	summary := hb.NewDiv().Role("alert").Attr("tabindex", "-1")
+	if theme.ErrorSummaryClass != "" {
+		summary.Class(theme.ErrorSummaryClass)
+	}
+
+	if title, found := form.message(MessageErrorSummaryTitle); found {
+		heading := hb.NewHeading2().HTML(title)
+		if theme.ErrorSummaryTitleClass != "" {
+			heading.Class(theme.ErrorSummaryTitleClass)
+		}
+		summary.Child(heading)
+	}
+
+	return summary.Child(list)
+}
+
+// errorKeys returns the keys of the field errors in the order the fields
+// appear in the form. Repeater item errors are listed by item, errors of
+// unknown fields last.
+func (form *Form) errorKeys() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+
+	add := func(key string) {
+		if _, exists := form.errors[key]; exists && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, field := range flattenFields(form.fields) {
+		repeater, ok := field.(*fieldRepeater)
+		if !ok {
+			add(field.GetName())
+			continue
+		}
+		itemFields := flattenFields(repeater.fields)
+		for _, index := range repeaterItemIndexes(repeater.GetName(), form.errors) {
+			for _, itemField := range itemFields {
+				add(repeaterItemFieldName(repeater.GetName(), itemField.GetName(), index))
+			}
+		}
+	}
+
+	rest := []string{}
+	for key := range form.errors {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// inputID returns the ID of the input rendered for the error key: the field's
+// ID, or for a repeater item field the ID of its rendered clone.
+// It returns an empty string when the key matches no rendered input.
+func (form *Form) inputID(key string) string {
+	if field := form.findField(key); field != nil {
+		return field.GetID()
+	}
+
+	for _, field := range flattenFields(form.fields) {
+		repeater, ok := field.(*fieldRepeater)
+		if !ok || !strings.HasPrefix(key, repeater.GetName()+"[") {
+			continue
+		}
+
+		fieldName, index, ok := splitRepeaterKey(key[len(repeater.GetName())+1:])
+		if !ok || index < 0 || index >= len(repeater.values) {
+			continue
+		}
+
+		// Mirrors the IDs assigned to the cloned item fields in BuildFormGroup
+		for fieldIndex, itemField := range repeater.fields {
+			if itemField.GetName() == fieldName {
+				return itemField.GetID() + "_" + strconv.Itoa(index) + "_" + strconv.Itoa(fieldIndex)
+			}
+		}
+	}
+
+	return ""
+}
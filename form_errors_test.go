@@ -96,8 +96,8 @@ func TestValidateThenBuildShowsErrors(t *testing.T) {
 	html := f.Build().ToHTML()
 
 	expecteds := []string{
-		`name is required`,
-		`email is required`,
+		`Name is required`,
+		`Email is required`,
 		`is-invalid`,
 		`invalid-feedback`,
 	}
@@ -149,3 +149,174 @@ func TestSetErrorsMethod(t *testing.T) {
 		t.Fatal("Expected GetErrors to return set errors")
 	}
 }
+
+func TestValidateKeepsAllMessagesPerField(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("code", "Code").WithValidators(
+			ValidatorMinLength(5),
+			ValidatorAlphaNumeric(),
+		),
+	)
+
+	errs := f.Validate(map[string]string{"code": "a-"})
+	if len(errs) != 2 {
+		t.Fatal("Expected 2 errors, got:", errs)
+	}
+
+	messages := f.GetFieldErrors()["code"]
+	if len(messages) != 2 {
+		t.Fatal("Expected 2 stored messages, got:", messages)
+	}
+	if f.GetErrors()["code"] != "Code must be at least 5 characters" {
+		t.Fatal("Expected GetErrors to return the first message, got:", f.GetErrors())
+	}
+
+	html := f.Build().ToHTML()
+
+	expected := `<div class="invalid-feedback"><ul class="list-unstyled mb-0"><li>Code must be at least 5 characters</li><li>Code must contain only letters and numbers</li></ul></div>`
+	if !strings.Contains(html, expected) {
+		t.Fatal("Expected:", expected, "got:", html)
+	}
+}
+
+func TestFieldErrorsInRow(t *testing.T) {
+	f := New().WithFields(
+		NewFieldRow(NewStringField("city", "City")),
+	).WithFieldErrors(map[string][]string{
+		"city": {"first", "second"},
+	})
+
+	html := f.Build().ToHTML()
+
+	if !strings.Contains(html, `<li>first</li><li>second</li>`) {
+		t.Fatal("Expected both messages in the row, got:", html)
+	}
+}
+
+func TestAddFormErrorRendersSummary(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("email", "Email"),
+	)
+	f.AddFormError("The email or password is incorrect")
+
+	if len(f.GetFormErrors()) != 1 {
+		t.Fatal("Expected 1 form error, got:", f.GetFormErrors())
+	}
+
+	html := f.Build().ToHTML()
+
+	expected := `<form method="POST"><div class="alert alert-danger" role="alert" tabindex="-1"><h2 class="alert-heading h5">There is a problem</h2><ul class="mb-0"><li>The email or password is incorrect</li></ul></div>`
+	if !strings.HasPrefix(html, expected) {
+		t.Fatal("Expected:", expected, "got:", html)
+	}
+}
+
+func TestErrorSummaryLinksToInputs(t *testing.T) {
+	f := New().WithErrorSummary(true).WithFields(
+		NewStringField("name", "Name").WithID("name_input").WithRequired(),
+		NewEmailField("email", "Email").WithID("email_input").WithRequired(),
+	)
+	f.Validate(map[string]string{})
+	f.AddFormError("Please try again")
+
+	html := f.Build().ToHTML()
+
+	expected := `<ul class="mb-0"><li>Please try again</li><li><a href="#name_input">Name is required</a></li><li><a href="#email_input">Email is required</a></li></ul>`
+	if !strings.Contains(html, expected) {
+		t.Fatal("Expected:", expected, "got:", html)
+	}
+	if !strings.Contains(html, `id="name_input"`) || !strings.Contains(html, `id="email_input"`) {
+		t.Fatal("Expected linked inputs to be rendered, got:", html)
+	}
+}
+
+func TestErrorSummaryLinksToGeneratedIDs(t *testing.T) {
+	f := New().WithErrorSummary(true).WithFields(
+		NewStringField("name", "Name").WithRequired(),
+	)
+	f.Validate(map[string]string{})
+
+	html := f.Build().ToHTML()
+
+	id := f.GetFields()[0].GetID()
+	if id == "" {
+		t.Fatal("Expected the field ID to be generated")
+	}
+	if !strings.Contains(html, `<a href="#`+id+`">Name is required</a>`) {
+		t.Fatal("Expected summary link to", id, "got:", html)
+	}
+}
+
+func TestErrorSummaryLinksToRepeaterItems(t *testing.T) {
+	f := New().WithErrorSummary(true).WithFields(
+		NewRepeater(RepeaterOptions{
+			Name: "addresses",
+			Fields: []FieldInterface{
+				NewStringField("street", "Street").WithID("street"),
+				NewStringField("city", "City").WithID("city").WithRequired(),
+			},
+			Values:      []map[string]string{{}, {}},
+			RepeaterUrl: "/repeater",
+		}),
+	)
+	f.Validate(map[string]string{
+		"addresses[street][0]": "", "addresses[city][0]": "Berlin",
+		"addresses[street][1]": "", "addresses[city][1]": "",
+	})
+
+	html := f.Build().ToHTML()
+
+	if !strings.Contains(html, `<a href="#city_1_1">City is required</a>`) {
+		t.Fatal("Expected summary link to the repeater item input, got:", html)
+	}
+	if !strings.Contains(html, `id="city_1_1"`) {
+		t.Fatal("Expected the repeater item input to have the linked ID, got:", html)
+	}
+}
+
+func TestErrorSummaryDisabledByDefault(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("name", "Name").WithRequired(),
+	)
+	f.Validate(map[string]string{})
+
+	html := f.Build().ToHTML()
+
+	if strings.Contains(html, `role="alert"`) {
+		t.Fatal("Should not render an error summary without form errors, got:", html)
+	}
+}
+
+func TestErrorSummaryNotShownWithoutErrors(t *testing.T) {
+	f := New().WithErrorSummary(true).WithFields(
+		NewStringField("name", "Name"),
+	)
+
+	html := f.Build().ToHTML()
+
+	if strings.Contains(html, `role="alert"`) {
+		t.Fatal("Should not render an empty error summary, got:", html)
+	}
+}
+
+func TestErrorSummaryLocalizedAndThemed(t *testing.T) {
+	tw := ThemeTailwind()
+	f := New().WithTheme(tw).WithLocale("de").WithErrorSummary(true).WithFields(
+		NewStringField("name", "Name").WithRequired(),
+	)
+	f.Validate(map[string]string{})
+
+	html := f.Build().ToHTML()
+
+	expecteds := []string{
+		`<div class="` + tw.ErrorSummaryClass + `" role="alert" tabindex="-1">`,
+		`<h2 class="` + tw.ErrorSummaryTitleClass + `">Es ist ein Problem aufgetreten</h2>`,
+		`<ul class="` + tw.ErrorSummaryListClass + `">`,
+		`Name ist erforderlich`,
+	}
+	for _, exp := range expecteds {
+		if !strings.Contains(html, exp) {
+			t.Fatal("Expected:", exp, "got:", html)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package form
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Strict parsing modes, deciding what ParseRequest does with submitted keys
+// that are not inputs of the form.
+const (
+	StrictDrop   = "drop"   // remove the unknown keys from the request
+	StrictReject = "reject" // return an *UnknownFieldsError
+)
+
+// ErrUnknownFields is matched, with errors.Is, by the error ParseRequest
+// returns when a form with StrictReject parsing receives unknown keys.
+var ErrUnknownFields = errors.New("form: unknown fields")
+
+// UnknownFieldsError is returned by ParseRequest when a form with
+// StrictReject parsing receives keys that are not inputs of the form.
+type UnknownFieldsError struct {
+	Names []string // the unknown keys, sorted
+}
+
+// Error returns the unknown keys.
+func (err *UnknownFieldsError) Error() string {
+	return ErrUnknownFields.Error() + ": " + strings.Join(err.Names, ", ")
+}
+
+// Is reports whether the target is ErrUnknownFields.
+func (err *UnknownFieldsError) Is(target error) bool {
+	return target == ErrUnknownFields
+}
+
+// WithStrictParsing makes ParseRequest check the submitted body and query
+// for keys that are not inputs of the form, such as an injected "is_admin".
+// With StrictDrop they are removed from the request's Form, PostForm and
+// URL query, so code binding them later cannot see them; with StrictReject the request
+// fails with an *UnknownFieldsError. An empty mode turns strict parsing off.
+func (form *Form) WithStrictParsing(mode string) *Form {
+	form.strictParsing = mode
+	return form
+}
+
+// FilterValues returns the submitted values whose keys are inputs of the
+// form: its fields, including those in field rows, the items of its
+// repeaters, and the inputs added by its CSRF, spam and idempotency
+// protection. Use it before binding submitted values to a struct.
+//
+// Submitted readonly and disabled fields, including those of repeater
+// items, get their server-side value, as in ParseValues, so binding cannot
+// change them.
+func (form *Form) FilterValues(values url.Values) url.Values {
+	filtered := url.Values{}
+	for key, submitted := range values {
+		if form.isKnownKey(key) {
+			filtered[key] = submitted
+		}
+	}
+
+	for _, field := range flattenFields(form.fields) {
+		if repeater, ok := field.(*fieldRepeater); ok {
+			repeater.setKeyring(form.keyring)
+			repeater.keepLockedValues(filtered)
+			continue
+		}
+
+		f, ok := field.(*Field)
+		if !ok || !(f.Readonly || f.Disabled) {
+			continue
+		}
+
+		_, named := filtered[f.Name]
+		_, listed := filtered[f.Name+"[]"]
+		if !named && !listed {
+			continue
+		}
+
+		filtered.Del(f.Name + "[]")
+		if f.Multiple {
+			filtered[f.Name] = append([]string{}, f.Values...)
+		} else {
+			filtered[f.Name] = []string{f.Value}
+		}
+	}
+
+	return filtered
+}
+
+// unknownKeys returns the sorted keys of the values that are not inputs of the form.
+func (form *Form) unknownKeys(values url.Values) []string {
+	unknown := []string{}
+	for key := range values {
+		if !form.isKnownKey(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// isKnownKey reports whether the key is the name of an input of the form.
+func (form *Form) isKnownKey(key string) bool {
+	switch key {
+	case "":
+		return false
+	case CSRFFieldName:
+		return form.csrf != nil
+	case IdempotencyFieldName:
+		return form.idempotency != nil
+	}
+
+	if form.spamProtection != nil && (key == SpamTimestampFieldName || key == form.spamProtection.HoneypotName) {
+		return true
+	}
+
+	for _, field := range flattenFields(form.fields) {
+		name := field.GetName()
+		if name == "" {
+			continue
+		}
+
+		switch field.GetType() {
+		case FORM_FIELD_TYPE_RAW, FORM_FIELD_TYPE_TABLE:
+			continue
+		}
+
+		if repeater, ok := field.(*fieldRepeater); ok {
+			if repeater.isItemKey(key) || repeater.isItemIDKey(key) {
+				return true
+			}
+			continue
+		}
+
+		if key == name {
+			return true
+		}
+		if f, ok := field.(*Field); ok && f.Multiple && key == name+"[]" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isItemKey reports whether the key is the input name of a field in one of
+// the repeater's items, e.g. "addresses[city][0]".
+func (field *fieldRepeater) isItemKey(key string) bool {
+	prefix := field.GetName() + `[`
+	if !strings.HasPrefix(key, prefix) {
+		return false
+	}
+
+	fieldName, _, ok := splitRepeaterKey(key[len(prefix):])
+	if !ok {
+		return false
+	}
+
+	for _, itemField := range flattenFields(field.fields) {
+		if itemField.GetName() == fieldName {
+			return true
+		}
+	}
+	return false
+}
+
+// checkUnknownKeys applies the strict parsing mode to the values of a parsed
+// request, from both its body and its query. Only the repeater actions are
+// accepted as query parameters that are not inputs of the form.
+func (form *Form) checkUnknownKeys(r *http.Request) error {
+	if form.strictParsing == "" {
+		return nil
+	}
+
+	query := r.URL.Query()
+	unknown := []string{}
+	for _, key := range form.unknownKeys(r.Form) {
+		if _, inQuery := query[key]; inQuery && isRepeaterAction(key) {
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	if form.strictParsing == StrictReject {
+		return &UnknownFieldsError{Names: unknown}
+	}
+
+	for _, key := range unknown {
+		r.Form.Del(key)
+		r.PostForm.Del(key)
+		if r.MultipartForm != nil {
+			delete(r.MultipartForm.Value, key)
+		}
+	}
+
+	queried := false
+	for _, key := range unknown {
+		if _, inQuery := query[key]; inQuery {
+			query.Del(key)
+			queried = true
+		}
+	}
+	if queried {
+		r.URL.RawQuery = query.Encode()
+	}
+	return nil
+}
+
+// isRepeaterAction reports whether the key is a repeater action parameter.
+func isRepeaterAction(key string) bool {
+	switch key {
+	case repeaterActionAdd, repeaterActionRemove, repeaterActionMoveUp, repeaterActionMoveDown:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+package form
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func filterTestForm() *Form {
+	return New().WithFields(
+		NewStringField("name", "Name"),
+		NewFieldRow(
+			NewSelectField("tags", "Tags", []FieldOption{{Key: "a", Value: "A"}}).WithMultiple(),
+		),
+		NewRawField("<hr>"),
+		NewRepeater(RepeaterOptions{
+			Name:   "items",
+			Fields: []FieldInterface{NewStringField("sku", "SKU")},
+		}),
+	)
+}
+
+func TestFilterValues(t *testing.T) {
+	submitted := url.Values{
+		"name":           {"Jane"},
+		"tags[]":         {"a"},
+		"items[sku][0]":  {"A"},
+		"items[sku][]":   {"B"},
+		"is_admin":       {"1"},
+		"items[role][0]": {"admin"},
+		"items":          {"x"},
+		"tags_Readonly":  {"a"},
+		CSRFFieldName:    {"token"},
+	}
+
+	filtered := filterTestForm().FilterValues(submitted)
+	expected := url.Values{
+		"name":          {"Jane"},
+		"tags[]":        {"a"},
+		"items[sku][0]": {"A"},
+		"items[sku][]":  {"B"},
+	}
+	if !reflect.DeepEqual(filtered, expected) {
+		t.Fatal("Unexpected filtered values:", filtered)
+	}
+
+	protected := filterTestForm().
+		WithCSRF(testCSRFProvider("s", time.Now())).
+		WithSpamProtection(SpamProtectionOptions{Key: testCSRFKey}).
+		WithIdempotency(NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{}))
+	filtered = protected.FilterValues(url.Values{
+		CSRFFieldName:          {"token"},
+		SpamTimestampFieldName: {"timestamp"},
+		"website":              {""},
+		IdempotencyFieldName:   {"token"},
+	})
+	if len(filtered) != 4 {
+		t.Fatal("Expected the protection inputs to be kept, got:", filtered)
+	}
+}
+
+func TestFilterValuesKeepsReadonlyAndDisabledValues(t *testing.T) {
+	teams := NewSelectField("teams", "Teams", []FieldOption{{Key: "a", Value: "A"}, {Key: "b", Value: "B"}}).
+		WithMultiple().WithDisabled()
+	teams.Values = []string{"a"}
+
+	f := New().WithFields(
+		NewStringField("email", "Email").WithValue("jane@example.com").WithReadonly(),
+		NewFieldRow(NewStringField("role", "Role").WithValue("member").WithDisabled()),
+		NewStringField("plan", "Plan").WithValue("free").WithReadonly(),
+		teams,
+		NewStringField("name", "Name"),
+	)
+
+	filtered := f.FilterValues(url.Values{
+		"email":   {"admin@example.com"},
+		"role":    {"admin"},
+		"teams[]": {"a", "b"},
+		"name":    {"Jane"},
+	})
+	expected := url.Values{
+		"email": {"jane@example.com"},
+		"role":  {"member"},
+		"teams": {"a"},
+		"name":  {"Jane"},
+	}
+	if !reflect.DeepEqual(filtered, expected) {
+		t.Fatal("Expected readonly and disabled fields to keep their values, got:", filtered)
+	}
+}
+
+func TestStrictParsingReject(t *testing.T) {
+	f := filterTestForm().WithStrictParsing(StrictReject)
+
+	_, err := f.ParseRequest(postForm(url.Values{"name": {"Jane"}, "is_admin": {"1"}, "role": {"admin"}}))
+	var unknown *UnknownFieldsError
+	if !errors.Is(err, ErrUnknownFields) || !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Names, []string{"is_admin", "role"}) {
+		t.Fatal("Expected the unknown fields to be rejected, got:", err)
+	}
+	if err.Error() != "form: unknown fields: is_admin, role" {
+		t.Fatal("Unexpected message:", err)
+	}
+	if f.findField("name").GetValue() != "" {
+		t.Fatal("Expected the values not to be parsed")
+	}
+
+	r := postForm(url.Values{"name": {"Jane"}})
+	r.URL.RawQuery = "is_admin=1"
+	if _, err := filterTestForm().WithStrictParsing(StrictReject).ParseRequest(r); !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Names, []string{"is_admin"}) {
+		t.Fatal("Expected unknown query parameters to be rejected, got:", err)
+	}
+
+	r = postForm(url.Values{"name": {"Jane"}, repeaterActionAdd: {"1"}})
+	if _, err := filterTestForm().WithStrictParsing(StrictReject).ParseRequest(r); !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Names, []string{repeaterActionAdd}) {
+		t.Fatal("Expected repeater actions in the body to be rejected, got:", err)
+	}
+
+	r = postForm(url.Values{"name": {"Jane"}})
+	r.URL.RawQuery = "repeatable_remove_index=0"
+	if values, err := filterTestForm().WithStrictParsing(StrictReject).ParseRequest(r); err != nil || values["name"] != "Jane" {
+		t.Fatal("Expected repeater actions in the query to pass, got:", values, err)
+	}
+}
+
+func TestStrictParsingDrop(t *testing.T) {
+	r := postForm(url.Values{"name": {"Jane"}, "is_admin": {"1"}, "page": {"3"}})
+	r.URL.RawQuery = "page=2&role=admin&repeatable_add=1"
+
+	values, err := filterTestForm().WithStrictParsing(StrictDrop).ParseRequest(r)
+	if err != nil || values["name"] != "Jane" {
+		t.Fatal("Expected the request to pass, got:", values, err)
+	}
+	for _, key := range []string{"is_admin", "page", "role"} {
+		if _, found := r.Form[key]; found || r.PostFormValue(key) != "" {
+			t.Fatal("Expected the unknown key", key, "to be removed from the request")
+		}
+	}
+	if r.FormValue(repeaterActionAdd) != "1" {
+		t.Fatal("Expected the repeater action to be kept")
+	}
+	if r.URL.RawQuery != "repeatable_add=1" {
+		t.Fatal("Expected the unknown keys to be removed from the query, got:", r.URL.RawQuery)
+	}
+}
+
+func TestStrictParsingDropMultipart(t *testing.T) {
+	body := "--b\r\nContent-Disposition: form-data; name=\"name\"\r\n\r\nJane\r\n" +
+		"--b\r\nContent-Disposition: form-data; name=\"is_admin\"\r\n\r\n1\r\n--b--\r\n"
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=b")
+
+	if _, err := filterTestForm().WithStrictParsing(StrictDrop).ParseRequest(r); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := r.MultipartForm.Value["is_admin"]; found || r.FormValue("is_admin") != "" {
+		t.Fatal("Expected the unknown field to be removed from the multipart form")
+	}
+}
+
+func TestParseValuesKeepsReadonlyAndDisabledValues(t *testing.T) {
+	teams := NewSelectField("teams", "Teams", []FieldOption{{Key: "a", Value: "A"}, {Key: "b", Value: "B"}}).
+		WithMultiple().WithReadonly()
+	teams.Values = []string{"a"}
+
+	f := New().WithFields(
+		NewStringField("email", "Email").WithValue("jane@example.com").WithReadonly(),
+		NewFieldRow(NewStringField("role", "Role").WithValue("member").WithDisabled()),
+		teams,
+		NewStringField("name", "Name"),
+	)
+
+	values := f.ParseValues(url.Values{
+		"email": {"admin@example.com"},
+		"role":  {"admin"},
+		"teams": {"a", "b"},
+		"name":  {"Jane"},
+	})
+
+	if values["email"] != "jane@example.com" || values["role"] != "member" || values["teams"] != `["a"]` || values["name"] != "Jane" {
+		t.Fatal("Expected readonly and disabled fields to keep their values, got:", values)
+	}
+	if f.findField("role").GetValue() != "member" {
+		t.Fatal("Expected the disabled field to keep its value, got:", f.findField("role").GetValue())
+	}
+}
+
+func readonlyRepeaterTestForm(t *testing.T) *Form {
+	return New().WithID("orderForm").WithKeyring(testKeyring(t, "k1", "k1")).WithFields(
+		NewRepeater(RepeaterOptions{
+			Name: "items",
+			Fields: []FieldInterface{
+				NewStringField("sku", "SKU").WithReadonly(),
+				NewStringField("price", "Price").WithDisabled(),
+				NewStringField("quantity", "Quantity"),
+			},
+			Values: []map[string]string{
+				{"sku": "A-1", "price": "10", "quantity": "1"},
+				{"sku": "B-2", "price": "20", "quantity": "1"},
+				{"sku": "C-3", "price": "30", "quantity": "1"},
+			},
+			RepeaterUrl: "/repeater",
+		}),
+	)
+}
+
+// renderedItemIDs returns the values of the item ID inputs of the page.
+func renderedItemIDs(t *testing.T, page string) url.Values {
+	t.Helper()
+
+	values := url.Values{}
+	for _, match := range regexp.MustCompile(`name="(items__item\[\d+\])"[^>]* value="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+		values.Set(match[1], match[2])
+	}
+	if len(values) == 0 {
+		t.Fatal("Expected item IDs, got:", page)
+	}
+	return values
+}
+
+func TestParseValuesKeepsReadonlyRepeaterItemValues(t *testing.T) {
+	ids := renderedItemIDs(t, readonlyRepeaterTestForm(t).Build().ToHTML())
+
+	// Item 1 was removed in the browser, a new item was added, and the
+	// locked values were changed
+	submitted := url.Values{
+		"items[sku][0]":      {"Z-9"},
+		"items[price][0]":    {"0"},
+		"items[quantity][0]": {"2"},
+		"items__item[0]":     ids["items__item[0]"],
+		"items[sku][2]":      {"A-1"},
+		"items[quantity][2]": {"3"},
+		"items__item[2]":     ids["items__item[2]"],
+		"items[sku][3]":      {"D-4"},
+		"items[price][3]":    {"1"},
+		"items[quantity][3]": {"4"},
+		"items__item[3]":     ids["items__item[0]"],
+	}
+	values := readonlyRepeaterTestForm(t).ParseValues(submitted)
+
+	expected := map[string]string{
+		"items[sku][0]": "A-1", "items[price][0]": "10", "items[quantity][0]": "2",
+		"items[sku][1]": "C-3", "items[price][1]": "30", "items[quantity][1]": "3",
+		"items[sku][2]": "", "items[price][2]": "", "items[quantity][2]": "4",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatal("Expected readonly and disabled item fields to keep their values, got:", values)
+	}
+}
+
+func TestRepeaterControllerKeepsReadonlyItemValues(t *testing.T) {
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm: func(r *http.Request) *Form {
+			return readonlyRepeaterTestForm(t)
+		},
+	})
+
+	serve := func(query string, submitted url.Values) string {
+		r := httptest.NewRequest(http.MethodPost, "/repeater?"+query, strings.NewReader(submitted.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		controller.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatal("Expected the action to run, got:", w.Code, w.Body.String())
+		}
+		return w.Body.String()
+	}
+
+	// submittedItems returns the values the browser submits for the page.
+	submittedItems := func(page string) url.Values {
+		submitted := renderedItemIDs(t, page)
+		for _, match := range regexp.MustCompile(`name="(items\[(?:sku|quantity)\]\[\d+\])"[^>]* value="([^"]*)"`).FindAllStringSubmatch(page, -1) {
+			submitted.Set(html.UnescapeString(match[1]), match[2])
+		}
+		return submitted
+	}
+
+	page := serve("repeatable_move_up_index=1", submittedItems(readonlyRepeaterTestForm(t).Build().ToHTML()))
+	page = serve("repeatable_remove_index=2", submittedItems(page))
+
+	values := readonlyRepeaterTestForm(t).ParseValues(submittedItems(page))
+	if values["items[sku][0]"] != "B-2" || values["items[price][0]"] != "20" || values["items[sku][1]"] != "A-1" || values["items[price][1]"] != "10" {
+		t.Fatal("Expected the locked values to follow their items, got:", values)
+	}
+	if _, found := values["items[sku][2]"]; found {
+		t.Fatal("Expected the removed item to be gone, got:", values)
+	}
+}
+
+func TestReadonlyRepeaterItemsNeedKeyring(t *testing.T) {
+	f := readonlyRepeaterTestForm(t).WithKeyring(nil)
+	if page := f.Build().ToHTML(); !strings.Contains(page, "needs a keyring") {
+		t.Fatal("Expected a keyring error, got:", page)
+	}
+}
+
+func TestFilterValuesKeepsReadonlyRepeaterItemValues(t *testing.T) {
+	ids := renderedItemIDs(t, readonlyRepeaterTestForm(t).Build().ToHTML())
+
+	filtered := readonlyRepeaterTestForm(t).FilterValues(url.Values{
+		"items[sku][0]":      {"Z-9"},
+		"items__item[0]":     ids["items__item[1]"],
+		"items[price][2]":    {"0"},
+		"items__item[2]":     ids["items__item[2]"],
+		"items[quantity][0]": {"2"},
+		"items[sku][3]":      {"D-4"},
+		"items[sku][]":       {"E-5"},
+	})
+
+	expected := url.Values{
+		"items[sku][0]":      {"B-2"},
+		"items__item[0]":     ids["items__item[1]"],
+		"items[price][2]":    {"30"},
+		"items__item[2]":     ids["items__item[2]"],
+		"items[quantity][0]": {"2"},
+	}
+	if !reflect.DeepEqual(filtered, expected) {
+		t.Fatal("Expected readonly and disabled item fields to keep their values, got:", filtered)
+	}
+}
@@ -73,13 +73,67 @@ func (form *Form) WithTheme(theme *Theme) *Form {
 // WithErrors sets validation error messages to display inline next to fields.
 // The map keys are field names, values are error messages.
 func (form *Form) WithErrors(errors map[string]string) *Form {
+	form.errors = singleErrors(errors)
+	return form
+}
+
+// WithFieldErrors sets validation error messages to display inline next to fields.
+// The map keys are field names, values are all the field's messages.
+func (form *Form) WithFieldErrors(errors map[string][]string) *Form {
 	form.errors = errors
 	return form
 }
 
+// WithErrorSummary enables an error summary at the top of the form, listing
+// the form errors and every field error with a link to the field's input.
+func (form *Form) WithErrorSummary(enabled bool) *Form {
+	form.errorSummary = enabled
+	return form
+}
+
+// WithRepeaterUrl sets the URL of the actions of the named repeater, served by
+// a RepeaterController, e.g. for repeaters of a form created by FromJSONSchema.
+// Repeaters inside field rows are found too; unknown names are ignored.
+func (form *Form) WithRepeaterUrl(repeaterName string, url string) *Form {
+	if repeater, ok := form.findField(repeaterName).(*fieldRepeater); ok {
+		repeater.repeaterUrl = url
+	}
+	return form
+}
+
+// WithNoValidate turns off the browser's checks of the constraint attributes
+// (required, minlength, maxlength, min, max, pattern) derived from the fields,
+// by rendering the form with the novalidate attribute. Validate still runs
+// the same rules on the server.
+func (form *Form) WithNoValidate(noValidate bool) *Form {
+	form.noValidate = noValidate
+	return form
+}
+
+// WithLocale sets the locale of validation messages, e.g. "de" or "es-MX".
+// Messages missing in the locale fall back to its base language, then to English.
+func (form *Form) WithLocale(locale string) *Form {
+	form.locale = locale
+	return form
+}
+
+// WithMessages sets the provider of validation message templates,
+// e.g. DefaultMessages() extended with more locales.
+func (form *Form) WithMessages(messages MessageProvider) *Form {
+	form.messages = messages
+	return form
+}
+
 // WithHTMX sets HTMX attributes on the form using a structured config.
 // This provides access to extended HTMX attributes beyond Post/Target/Swap.
 func (form *Form) WithHTMX(config HTMXConfig) *Form {
 	form.htmxConfig = &config
 	return form
 }
+
+// WithValidationConcurrency sets how many context validators ValidateContext
+// may run at once. Values below 2 run them one at a time (the default).
+func (form *Form) WithValidationConcurrency(limit int) *Form {
+	form.validationConcurrency = limit
+	return form
+}
@@ -0,0 +1,287 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// dateTimePattern matches the values submitted by datetime inputs, with
+// optional seconds, e.g. "2024-03-15T10:30".
+const dateTimePattern = `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}(:[0-9]{2})?$`
+
+// jsonSchemaDialect is the JSON Schema draft used by Form.JSONSchema.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of a JSON Schema (draft 2020-12) produced for forms.
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Const       *string                `json:"const,omitempty"`
+	Enum        []string               `json:"enum,omitempty"`
+	OneOf       []*jsonSchema          `json:"oneOf,omitempty"`
+	MinLength   *int                   `json:"minLength,omitempty"`
+	MaxLength   *int                   `json:"maxLength,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	AllOf       []*jsonSchema          `json:"allOf,omitempty"`
+	ReadOnly    bool                   `json:"readOnly,omitempty"`
+	WriteOnly   bool                   `json:"writeOnly,omitempty"`
+	Items       *jsonSchema            `json:"items,omitempty"`
+	UniqueItems bool                   `json:"uniqueItems,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the values the
+// form accepts, as a contract for frontends and APIs.
+//
+// Each field becomes a property typed after the field's type, e.g. email
+// fields are strings with format "email", numbers are numbers and checkboxes
+// booleans. Labels and help become title and description, options become
+// oneOf with titles, and the field's rules become keywords such as minLength,
+// maximum and pattern. Required fields are listed in required. Repeaters
+// become arrays of objects. Raw, table and file fields are left out, as they
+// carry no value in the submitted data.
+func (form *Form) JSONSchema() ([]byte, error) {
+	schema, err := objectSchema(form.fields)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Schema = jsonSchemaDialect
+
+	return json.Marshal(schema)
+}
+
+// objectSchema returns the schema of an object with a property for each field.
+func objectSchema(fields []FieldInterface) (*jsonSchema, error) {
+	schema := &jsonSchema{
+		Type:       "object",
+		Properties: map[string]*jsonSchema{},
+	}
+
+	for _, field := range flattenFields(fields) {
+		property, err := fieldSchema(field)
+		if err != nil {
+			return nil, err
+		}
+		if property == nil {
+			continue
+		}
+
+		schema.Properties[field.GetName()] = property
+
+		if field.GetRequired() || hasRule(field, RuleRequired) {
+			schema.Required = append(schema.Required, field.GetName())
+		}
+	}
+
+	return schema, nil
+}
+
+// fieldSchema returns the schema of the field's value, or nil if the field
+// has no value in the submitted data.
+func fieldSchema(field FieldInterface) (*jsonSchema, error) {
+	if field.GetName() == "" {
+		return nil, nil
+	}
+
+	if repeater, ok := field.(*fieldRepeater); ok {
+		items, err := objectSchema(repeater.fields)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{
+			Type:        "array",
+			Title:       repeater.GetLabel(),
+			Description: repeater.GetHelp(),
+			Items:       items,
+		}, nil
+	}
+
+	schema := &jsonSchema{
+		Title:       field.GetLabel(),
+		Description: field.GetHelp(),
+	}
+
+	switch field.GetType() {
+	case FORM_FIELD_TYPE_RAW, FORM_FIELD_TYPE_TABLE, FORM_FIELD_TYPE_FILE:
+		return nil, nil
+	case FORM_FIELD_TYPE_NUMBER:
+		schema.Type = "number"
+	case FORM_FIELD_TYPE_CHECKBOX:
+		schema.Type = "boolean"
+	case FORM_FIELD_TYPE_EMAIL:
+		schema.Type = "string"
+		schema.Format = "email"
+	case FORM_FIELD_TYPE_URL:
+		schema.Type = "string"
+		schema.Format = "uri"
+	case FORM_FIELD_TYPE_DATE:
+		schema.Type = "string"
+		schema.Format = "date"
+	case FORM_FIELD_TYPE_DATETIME:
+		// Not format date-time: the input submits no seconds or offset, which RFC 3339 requires
+		schema.Type = "string"
+		schema.Pattern = dateTimePattern
+	case FORM_FIELD_TYPE_PASSWORD:
+		schema.Type = "string"
+		schema.WriteOnly = true
+	default:
+		schema.Type = "string"
+	}
+
+	options := fieldOptions(field)
+	if len(options) > 0 && schema.Type == "string" {
+		schema.OneOf = optionSchemas(options)
+	}
+
+	f, ok := field.(*Field)
+	if !ok {
+		return schema, nil
+	}
+
+	schema.ReadOnly = f.Readonly
+
+	if err := applyRuleKeywords(schema, f.GetRules()); err != nil {
+		return nil, errors.New("form: field " + f.Name + ": " + err.Error())
+	}
+
+	if f.Multiple {
+		// A multiple select submits a list of the selected options, each
+		// checked by the options and rules
+		items := *schema
+		items.Title = ""
+		items.Description = ""
+		items.ReadOnly = false
+
+		schema = &jsonSchema{
+			Type:        "array",
+			Title:       schema.Title,
+			Description: schema.Description,
+			ReadOnly:    schema.ReadOnly,
+			Items:       &items,
+			UniqueItems: true,
+		}
+	}
+
+	return schema, nil
+}
+
+// fieldOptions returns the field's options, including those of its options function.
+func fieldOptions(field FieldInterface) []FieldOption {
+	options := append([]FieldOption{}, field.GetOptions()...)
+	if optionsF := field.GetOptionsF(); optionsF != nil {
+		options = append(options, optionsF()...)
+	}
+	return options
+}
+
+// optionSchemas returns a const schema titled with the option's label for each option.
+func optionSchemas(options []FieldOption) []*jsonSchema {
+	schemas := make([]*jsonSchema, len(options))
+	for i, option := range options {
+		key := option.Key
+		schemas[i] = &jsonSchema{Const: &key, Title: option.Value}
+	}
+	return schemas
+}
+
+// applyRuleKeywords adds the JSON Schema keywords of the rules to the schema.
+// Rules without an equivalent keyword, such as cross-field rules, are left out.
+func applyRuleKeywords(schema *jsonSchema, rules []Rule) error {
+	for _, rule := range rules {
+		params := rule.Params()
+
+		switch rule.Name() {
+		case RuleMinLength:
+			minLength, err := strconv.Atoi(params["min"])
+			if err != nil {
+				return errors.New("invalid " + RuleMinLength + " " + params["min"])
+			}
+			schema.MinLength = &minLength
+		case RuleMaxLength:
+			maxLength, err := strconv.Atoi(params["max"])
+			if err != nil {
+				return errors.New("invalid " + RuleMaxLength + " " + params["max"])
+			}
+			schema.MaxLength = &maxLength
+		case RuleMin:
+			minimum, err := strconv.ParseFloat(params["min"], 64)
+			if err != nil {
+				return errors.New("invalid " + RuleMin + " " + params["min"])
+			}
+			schema.Minimum = &minimum
+		case RuleMax:
+			maximum, err := strconv.ParseFloat(params["max"], 64)
+			if err != nil {
+				return errors.New("invalid " + RuleMax + " " + params["max"])
+			}
+			schema.Maximum = &maximum
+		case RuleInteger:
+			if schema.Type == "number" || schema.Type == "string" {
+				schema.Type = "integer"
+			}
+		case RulePattern:
+			addPattern(schema, params["pattern"])
+		case RuleEmail, RuleEmailAddress:
+			schema.Format = "email"
+		case RuleURL:
+			schema.Format = "uri"
+		case RuleIP:
+			schema.Format = "ipv4"
+		case RuleIPv6:
+			schema.Format = "ipv6"
+		case RuleHostname:
+			schema.Format = "hostname"
+		case RuleUUID:
+			schema.Format = "uuid"
+		case RuleAlphaNumeric:
+			addPattern(schema, alphaNumericPattern)
+		case RuleOneOf:
+			if values, err := parseListParam(params["values"]); err == nil && len(values) > 0 {
+				schema.Enum = values
+			}
+		}
+	}
+
+	return nil
+}
+
+// addPattern adds a pattern the values must match to the schema. A schema has
+// a single pattern keyword, so further patterns, e.g. a rule's pattern on a
+// datetime field, are added in allOf, and the value must match all of them.
+func addPattern(schema *jsonSchema, pattern string) {
+	if schema.Pattern == "" {
+		schema.Pattern = pattern
+		return
+	}
+	if schema.Pattern == pattern {
+		return
+	}
+	for _, other := range schema.AllOf {
+		if other.Pattern == pattern {
+			return
+		}
+	}
+	schema.AllOf = append(schema.AllOf, &jsonSchema{Pattern: pattern})
+}
+
+// hasRule reports whether the field has a rule with the name.
+func hasRule(field FieldInterface, name string) bool {
+	f, ok := field.(*Field)
+	if !ok {
+		return false
+	}
+	for _, rule := range f.GetRules() {
+		if rule.Name() == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,555 @@
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// UnsupportedKeywordsError reports the JSON Schema keywords FromJSONSchema
+// could not map to the form. Each keyword is a JSON Pointer into the schema,
+// e.g. "/properties/age/multipleOf".
+type UnsupportedKeywordsError struct {
+	Keywords []string
+}
+
+// Error lists the unsupported keywords.
+func (err *UnsupportedKeywordsError) Error() string {
+	return "form: unsupported JSON Schema keywords: " + strings.Join(err.Keywords, ", ")
+}
+
+// annotationKeywords are JSON Schema keywords that describe a schema without
+// constraining the values; they are accepted, and title, description and
+// default are used for the fields.
+var annotationKeywords = []string{
+	"$schema", "$id", "$comment", "title", "description", "default",
+	"examples", "deprecated", "readOnly", "writeOnly",
+}
+
+// FromJSONSchema creates a form from a JSON Schema document describing an object,
+// e.g. one exported by Form.JSONSchema:
+//
+//   - string properties become string fields, or email, url, date and datetime
+//     fields by their format, and password fields when writeOnly; date and
+//     datetime fields get ValidatorDate;
+//   - number and integer properties become number fields, boolean properties checkboxes;
+//   - enum and oneOf (of consts with titles) become select options;
+//   - minLength, maxLength, pattern, minimum and maximum become validators,
+//     and the ipv4, ipv6, hostname and uuid formats ValidatorIP,
+//     ValidatorIPv6, ValidatorHostname and ValidatorUUID;
+//   - nested objects become field rows, with their fields named "object[property]";
+//   - arrays of enums become multiple selects, arrays of objects become repeaters;
+//   - title, description, default, readOnly and required set the field's label,
+//     help, value, readonly and required flags.
+//
+// Keywords that cannot be mapped are reported in an *UnsupportedKeywordsError,
+// returned together with the form built from the rest of the schema. Callers
+// that accept partial forms can check for it with errors.As.
+//
+// Repeaters need the URL of their actions; set it with Form.WithRepeaterUrl.
+// The optional FormOptions configure the form itself; the generated fields
+// are appended after any fields it already contains.
+func FromJSONSchema(schema []byte, opts ...FormOptions) (*Form, error) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, errors.New("form: invalid JSON Schema: " + err.Error())
+	}
+
+	if schemaType, _ := schemaType(root); schemaType != "object" && root["properties"] == nil {
+		return nil, errors.New("form: FromJSONSchema requires a schema of type object")
+	}
+
+	reader := &jsonSchemaReader{}
+
+	fields, err := reader.objectFields(root, "", "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	options := FormOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	form := NewForm(options)
+	form.fields = append(append([]FieldInterface{}, form.fields...), fields...)
+
+	if len(reader.unsupported) > 0 {
+		return form, &UnsupportedKeywordsError{Keywords: reader.unsupported}
+	}
+
+	return form, nil
+}
+
+// jsonSchemaReader creates fields from JSON Schema objects, collecting
+// the keywords it cannot map.
+type jsonSchemaReader struct {
+	unsupported []string
+}
+
+// objectFields returns a field for each property of the object schema at the path.
+// Field names are prefixed with the names of the enclosing objects.
+func (reader *jsonSchemaReader) objectFields(schema map[string]json.RawMessage, path string, prefix string, inRepeater bool) ([]FieldInterface, error) {
+	reader.checkKeywords(schema, path, "type", "properties", "required")
+
+	required := []string{}
+	if raw, ok := schema["required"]; ok {
+		if err := json.Unmarshal(raw, &required); err != nil {
+			return nil, errors.New("form: invalid JSON Schema " + path + "/required: " + err.Error())
+		}
+	}
+
+	names, properties, err := orderedProperties(schema["properties"])
+	if err != nil {
+		return nil, errors.New("form: invalid JSON Schema " + path + "/properties: " + err.Error())
+	}
+
+	fields := []FieldInterface{}
+
+	for _, name := range names {
+		propertyPath := path + "/properties/" + escapePointer(name)
+
+		fieldName := name
+		if prefix != "" {
+			fieldName = prefix + "[" + name + "]"
+		}
+
+		field, err := reader.propertyField(properties[name], propertyPath, fieldName, inRepeater)
+		if err != nil {
+			return nil, err
+		}
+		if field == nil {
+			continue
+		}
+
+		if lo.Contains(required, name) {
+			switch field.(type) {
+			case *fieldRow:
+				// The nested object's own required list applies to its fields
+			case *fieldRepeater:
+				// Repeaters cannot be required
+				reader.report(path + "/required")
+			default:
+				field.SetRequired(true)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// propertyField returns the field for the property schema at the path,
+// or nil when the property cannot be mapped to a field.
+func (reader *jsonSchemaReader) propertyField(raw json.RawMessage, path string, name string, inRepeater bool) (FieldInterface, error) {
+	var schema map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, errors.New("form: invalid JSON Schema " + path + ": " + err.Error())
+	}
+
+	propertyType, ok := schemaType(schema)
+	if !ok {
+		reader.report(path + "/type")
+	}
+
+	title := schemaString(schema, "title")
+	description := schemaString(schema, "description")
+
+	switch propertyType {
+	case "object":
+		if inRepeater {
+			reader.report(path)
+			return nil, nil
+		}
+		fields, err := reader.objectFields(schema, path, name, inRepeater)
+		if err != nil {
+			return nil, err
+		}
+		return NewFieldRow(fields...), nil
+	case "array":
+		return reader.arrayField(schema, path, name, title, description, inRepeater)
+	}
+
+	field := reader.valueField(schema, path, propertyType)
+	field.Name = name
+	field.Label = lo.Ternary(title != "", title, name)
+	field.Help = description
+	field.Readonly = schemaBool(schema, "readOnly")
+	field.Value = defaultValue(schema["default"])
+
+	return field, nil
+}
+
+// valueField returns the field for a string, number, integer or boolean schema.
+func (reader *jsonSchemaReader) valueField(schema map[string]json.RawMessage, path string, propertyType string) *Field {
+	reader.checkKeywords(schema, path, "type", "format", "enum", "oneOf",
+		"minLength", "maxLength", "pattern", "allOf", "minimum", "maximum")
+
+	field := &Field{Type: FORM_FIELD_TYPE_STRING}
+
+	switch propertyType {
+	case "number":
+		field.Type = FORM_FIELD_TYPE_NUMBER
+	case "integer":
+		field.Type = FORM_FIELD_TYPE_NUMBER
+		field.Validators = append(field.Validators, ValidatorInteger())
+	case "boolean":
+		field.Type = FORM_FIELD_TYPE_CHECKBOX
+	}
+
+	if format := schemaString(schema, "format"); format != "" {
+		switch format {
+		case "email":
+			field.Type = FORM_FIELD_TYPE_EMAIL
+			field.Validators = append(field.Validators, ValidatorEmail())
+		case "uri", "url":
+			field.Type = FORM_FIELD_TYPE_URL
+			field.Validators = append(field.Validators, ValidatorURL())
+		case "date":
+			field.Type = FORM_FIELD_TYPE_DATE
+			field.Validators = append(field.Validators, ValidatorDate(dateLayout))
+		case "date-time":
+			// Datetime inputs submit no offset, so values are checked as
+			// submitted by the input rather than as RFC 3339
+			field.Type = FORM_FIELD_TYPE_DATETIME
+			field.Validators = append(field.Validators, ValidatorDate(""))
+		case "ipv4":
+			field.Validators = append(field.Validators, ValidatorIP())
+		case "ipv6":
+			field.Validators = append(field.Validators, ValidatorIPv6())
+		case "hostname":
+			field.Validators = append(field.Validators, ValidatorHostname())
+		case "uuid":
+			field.Validators = append(field.Validators, ValidatorUUID())
+		default:
+			reader.report(path + "/format")
+		}
+	}
+
+	if field.Type == FORM_FIELD_TYPE_STRING && schemaString(schema, "pattern") == dateTimePattern {
+		// The pattern exported for datetime fields
+		field.Type = FORM_FIELD_TYPE_DATETIME
+		field.Validators = append(field.Validators, ValidatorDate(""))
+		delete(schema, "pattern")
+	}
+
+	if schemaBool(schema, "writeOnly") && field.Type == FORM_FIELD_TYPE_STRING {
+		field.Type = FORM_FIELD_TYPE_PASSWORD
+	}
+
+	if options := reader.options(schema, path); len(options) > 0 {
+		field.Type = FORM_FIELD_TYPE_SELECT
+		field.Options = options
+	}
+
+	reader.addRules(field, schema, path)
+
+	return field
+}
+
+// arrayField returns a multiple select for an array of options,
+// or a repeater for an array of objects.
+func (reader *jsonSchemaReader) arrayField(schema map[string]json.RawMessage, path string, name string, title string, description string, inRepeater bool) (FieldInterface, error) {
+	reader.checkKeywords(schema, path, "type", "items", "uniqueItems")
+
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(schema["items"], &items); err != nil || items == nil {
+		reader.report(path + "/items")
+		return nil, nil
+	}
+
+	itemsType, _ := schemaType(items)
+
+	if itemsType == "object" && !inRepeater {
+		fields, err := reader.objectFields(items, path+"/items", "", true)
+		if err != nil {
+			return nil, err
+		}
+		return NewRepeater(RepeaterOptions{
+			Name:   name,
+			Label:  lo.Ternary(title != "", title, name),
+			Help:   description,
+			Fields: fields,
+		}), nil
+	}
+
+	options := reader.options(items, path+"/items")
+	if len(options) == 0 || inRepeater {
+		reader.report(path + "/items")
+		return nil, nil
+	}
+
+	reader.checkKeywords(items, path+"/items", "type", "enum", "oneOf")
+
+	field := NewSelectField(name, lo.Ternary(title != "", title, name), options).WithMultiple()
+	field.Help = description
+	field.Readonly = schemaBool(schema, "readOnly")
+
+	var defaults []any
+	if raw, ok := schema["default"]; ok && json.Unmarshal(raw, &defaults) == nil {
+		for _, value := range defaults {
+			encoded, _ := json.Marshal(value)
+			field.Values = append(field.Values, defaultValue(encoded))
+		}
+		field.Value = joinSelected(field.Values)
+	}
+
+	return field, nil
+}
+
+// options returns the options of an enum, or of a oneOf of consts with titles.
+func (reader *jsonSchemaReader) options(schema map[string]json.RawMessage, path string) []FieldOption {
+	options := []FieldOption{}
+
+	if raw, ok := schema["enum"]; ok {
+		var values []json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			reader.report(path + "/enum")
+			return nil
+		}
+		for _, value := range values {
+			key := defaultValue(value)
+			options = append(options, FieldOption{Key: key, Value: key})
+		}
+	}
+
+	if raw, ok := schema["oneOf"]; ok {
+		var alternatives []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &alternatives); err != nil {
+			reader.report(path + "/oneOf")
+			return nil
+		}
+		for _, alternative := range alternatives {
+			constant, ok := alternative["const"]
+			if !ok {
+				reader.report(path + "/oneOf")
+				return nil
+			}
+			key := defaultValue(constant)
+			title := schemaString(alternative, "title")
+			options = append(options, FieldOption{Key: key, Value: lo.Ternary(title != "", title, key)})
+		}
+	}
+
+	return options
+}
+
+// addRules adds the validators of the schema's constraint keywords to the field.
+func (reader *jsonSchemaReader) addRules(field *Field, schema map[string]json.RawMessage, path string) {
+	if raw, ok := schema["minLength"]; ok {
+		if n, err := strconv.Atoi(string(raw)); err == nil {
+			field.Validators = append(field.Validators, ValidatorMinLength(n))
+		} else {
+			reader.report(path + "/minLength")
+		}
+	}
+
+	if raw, ok := schema["maxLength"]; ok {
+		if n, err := strconv.Atoi(string(raw)); err == nil {
+			field.Validators = append(field.Validators, ValidatorMaxLength(n))
+		} else {
+			reader.report(path + "/maxLength")
+		}
+	}
+
+	if raw, ok := schema["pattern"]; ok {
+		var pattern string
+		if err := json.Unmarshal(raw, &pattern); err == nil && isValidPattern(pattern) {
+			field.Validators = append(field.Validators, ValidatorPattern(pattern, ""))
+		} else {
+			reader.report(path + "/pattern")
+		}
+	}
+
+	if raw, ok := schema["allOf"]; ok {
+		reader.addPatterns(field, raw, path+"/allOf")
+	}
+
+	if raw, ok := schema["minimum"]; ok {
+		if n, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			field.Validators = append(field.Validators, ValidatorMin(n))
+		} else {
+			reader.report(path + "/minimum")
+		}
+	}
+
+	if raw, ok := schema["maximum"]; ok {
+		if n, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			field.Validators = append(field.Validators, ValidatorMax(n))
+		} else {
+			reader.report(path + "/maximum")
+		}
+	}
+}
+
+// checkKeywords reports the schema's keywords that are neither annotations
+// nor in the supported list.
+func (reader *jsonSchemaReader) checkKeywords(schema map[string]json.RawMessage, path string, supported ...string) {
+	keywords := make([]string, 0, len(schema))
+	for keyword := range schema {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		if !lo.Contains(supported, keyword) && !lo.Contains(annotationKeywords, keyword) {
+			reader.report(path + "/" + escapePointer(keyword))
+		}
+	}
+}
+
+// report records an unsupported keyword, once.
+func (reader *jsonSchemaReader) report(pointer string) {
+	if !lo.Contains(reader.unsupported, pointer) {
+		reader.unsupported = append(reader.unsupported, pointer)
+	}
+}
+
+// addPatterns adds the patterns of an allOf of pattern schemas, as exported
+// for fields with several patterns, to the field. Other schemas are reported.
+func (reader *jsonSchemaReader) addPatterns(field *Field, raw json.RawMessage, path string) {
+	var schemas []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &schemas); err != nil {
+		reader.report(path)
+		return
+	}
+
+	for i, schema := range schemas {
+		var pattern string
+		if len(schema) != 1 || json.Unmarshal(schema["pattern"], &pattern) != nil || !isValidPattern(pattern) {
+			reader.report(path + "/" + strconv.Itoa(i))
+			continue
+		}
+		field.Validators = append(field.Validators, ValidatorPattern(pattern, ""))
+	}
+}
+
+// isValidPattern reports whether the pattern compiles as a Go regular expression.
+func isValidPattern(pattern string) bool {
+	_, err := regexp.Compile(pattern)
+	return err == nil
+}
+
+// schemaType returns the schema's type, picking the non-null type of a
+// nullable type such as ["string", "null"]. A missing type is an object
+// when the schema has properties, else a string. It reports false when
+// the type cannot be mapped.
+func schemaType(schema map[string]json.RawMessage) (string, bool) {
+	raw, ok := schema["type"]
+	if !ok {
+		if schema["properties"] != nil {
+			return "object", true
+		}
+		return "string", true
+	}
+
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single, lo.Contains([]string{"object", "array", "string", "number", "integer", "boolean"}, single)
+	}
+
+	var multiple []string
+	if json.Unmarshal(raw, &multiple) == nil {
+		types := []string{}
+		for _, t := range multiple {
+			if t != "null" {
+				types = append(types, t)
+			}
+		}
+		if len(types) == 1 {
+			return types[0], true
+		}
+	}
+
+	return "string", false
+}
+
+// orderedProperties returns the property names in document order, with their schemas.
+func orderedProperties(raw json.RawMessage) ([]string, map[string]json.RawMessage, error) {
+	names := []string{}
+	properties := map[string]json.RawMessage{}
+
+	if raw == nil {
+		return names, properties, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, nil, errors.New("properties must be an object")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		name := token.(string)
+
+		var property json.RawMessage
+		if err := decoder.Decode(&property); err != nil {
+			return nil, nil, err
+		}
+
+		if _, exists := properties[name]; !exists {
+			names = append(names, name)
+		}
+		properties[name] = property
+	}
+
+	return names, properties, nil
+}
+
+// schemaString returns the string value of the keyword, or "".
+func schemaString(schema map[string]json.RawMessage, keyword string) string {
+	var value string
+	_ = json.Unmarshal(schema[keyword], &value)
+	return value
+}
+
+// schemaBool returns the boolean value of the keyword, or false.
+func schemaBool(schema map[string]json.RawMessage, keyword string) bool {
+	var value bool
+	_ = json.Unmarshal(schema[keyword], &value)
+	return value
+}
+
+// defaultValue returns a JSON value as a field value: strings as is,
+// true as "1", false and null as "", numbers as written.
+func defaultValue(raw json.RawMessage) string {
+	if raw == nil {
+		return ""
+	}
+
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return lo.Ternary(v, "1", "")
+	case nil:
+		return ""
+	}
+
+	return string(raw)
+}
+
+// escapePointer escapes a name for use in a JSON Pointer.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,275 @@
+package form
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFromJSONSchema(t *testing.T) {
+	schema := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "title": "Name", "description": "Your full name", "minLength": 2, "maxLength": 50, "pattern": "^[A-Za-z ]+$"},
+			"email": {"type": "string", "format": "email", "title": "Email"},
+			"age": {"type": "integer", "minimum": 18, "maximum": 120, "default": 30},
+			"birthday": {"type": "string", "format": "date"},
+			"meeting": {"type": "string", "format": "date-time"},
+			"website": {"type": ["string", "null"], "format": "uri"},
+			"password": {"type": "string", "writeOnly": true},
+			"agree": {"type": "boolean", "default": true},
+			"size": {"type": "string", "enum": ["s", "m", "l"]},
+			"country": {"type": "string", "oneOf": [{"const": "de", "title": "Germany"}, {"const": "es", "title": "Spain"}]},
+			"tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}, "uniqueItems": true, "default": ["b"]},
+			"code": {"type": "string", "readOnly": true}
+		},
+		"required": ["name", "email"]
+	}`
+
+	f, err := FromJSONSchema([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, field := range f.GetFields() {
+		names = append(names, field.GetName())
+	}
+	expectedNames := []string{"name", "email", "age", "birthday", "meeting", "website", "password", "agree", "size", "country", "tags", "code"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatal("Expected fields in document order, got:", names)
+	}
+
+	field := func(name string) *Field {
+		return f.findField(name).(*Field)
+	}
+
+	name := field("name")
+	if name.Type != FORM_FIELD_TYPE_STRING || name.Label != "Name" || name.Help != "Your full name" || !name.Required {
+		t.Fatal("Unexpected name field:", name)
+	}
+	assertRuleNames(t, name, RuleMinLength, RuleMaxLength, RulePattern)
+
+	email := field("email")
+	if email.Type != FORM_FIELD_TYPE_EMAIL || !email.Required {
+		t.Fatal("Unexpected email field:", email)
+	}
+	assertRuleNames(t, email, RuleEmail)
+
+	age := field("age")
+	if age.Type != FORM_FIELD_TYPE_NUMBER || age.Label != "age" || age.Value != "30" || age.constraintAttrs()["step"] != "1" {
+		t.Fatal("Unexpected age field:", age)
+	}
+	assertRuleNames(t, age, RuleInteger, RuleMin, RuleMax)
+	if err := age.Validators[0].Validate("age", "30.5", nil); err == nil || err.Message != "age must be a whole number" {
+		t.Fatal("Expected the integer message, got:", err)
+	}
+
+	types := map[string]string{
+		"birthday": FORM_FIELD_TYPE_DATE,
+		"meeting":  FORM_FIELD_TYPE_DATETIME,
+		"website":  FORM_FIELD_TYPE_URL,
+		"password": FORM_FIELD_TYPE_PASSWORD,
+		"agree":    FORM_FIELD_TYPE_CHECKBOX,
+		"size":     FORM_FIELD_TYPE_SELECT,
+		"country":  FORM_FIELD_TYPE_SELECT,
+		"tags":     FORM_FIELD_TYPE_SELECT,
+	}
+	for name, fieldType := range types {
+		if field(name).Type != fieldType {
+			t.Fatal("Expected", name, "to be", fieldType, "got:", field(name).Type)
+		}
+	}
+
+	assertRuleNames(t, field("birthday"), RuleDate)
+	assertRuleNames(t, field("meeting"), RuleDate)
+
+	if field("agree").Value != "1" {
+		t.Fatal("Expected boolean default to check the checkbox")
+	}
+	if !reflect.DeepEqual(field("size").Options, []FieldOption{{Key: "s", Value: "s"}, {Key: "m", Value: "m"}, {Key: "l", Value: "l"}}) {
+		t.Fatal("Unexpected enum options:", field("size").Options)
+	}
+	if !reflect.DeepEqual(field("country").Options, []FieldOption{{Key: "de", Value: "Germany"}, {Key: "es", Value: "Spain"}}) {
+		t.Fatal("Unexpected oneOf options:", field("country").Options)
+	}
+	if tags := field("tags"); !tags.Multiple || !reflect.DeepEqual(tags.Values, []string{"b"}) {
+		t.Fatal("Unexpected tags field:", tags)
+	}
+	if !field("code").Readonly {
+		t.Fatal("Expected code to be readonly")
+	}
+
+	AssertValidationFailsOn(t, f, map[string]string{"name": "J", "email": "jane@example.com"}, "name")
+	AssertValidationFailsOn(t, f, map[string]string{"name": "Jane", "email": "jane@example.com", "age": "18.5"}, "age")
+	AssertValidationFailsOn(t, f, map[string]string{"name": "Jane", "email": "jane@example.com", "birthday": "1990-02-30"}, "birthday")
+	AssertValidationFailsOn(t, f, map[string]string{"name": "Jane", "email": "jane@example.com", "meeting": "tomorrow"}, "meeting")
+	AssertValidationPasses(t, f, map[string]string{"name": "Jane", "email": "jane@example.com", "age": "42", "birthday": "1990-05-17", "meeting": "2024-03-15T10:30"})
+}
+
+func assertRuleNames(t *testing.T, field *Field, names ...string) {
+	t.Helper()
+	ruleNames := []string{}
+	for _, rule := range field.GetRules() {
+		ruleNames = append(ruleNames, rule.Name())
+	}
+	if !reflect.DeepEqual(ruleNames, names) {
+		t.Fatal("Expected rules", names, "got:", ruleNames)
+	}
+}
+
+func TestFromJSONSchemaNestedObject(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string"},
+					"city": {"type": "string", "title": "City"}
+				},
+				"required": ["city"]
+			}
+		}
+	}`
+
+	f, err := FromJSONSchema([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := f.GetFields()[0].(*fieldRow); !ok {
+		t.Fatal("Expected a field row for the nested object, got:", f.GetFields()[0])
+	}
+
+	city, ok := f.findField("address[city]").(*Field)
+	if !ok || city.Label != "City" || !city.Required {
+		t.Fatal("Unexpected nested field:", city)
+	}
+	if f.findField("address[street]") == nil {
+		t.Fatal("Expected address[street] field")
+	}
+}
+
+func TestFromJSONSchemaRepeater(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"properties": {
+			"addresses": {
+				"type": "array",
+				"title": "Addresses",
+				"items": {
+					"type": "object",
+					"properties": {
+						"street": {"type": "string"},
+						"city": {"type": "string", "minLength": 2}
+					},
+					"required": ["city"]
+				}
+			}
+		}
+	}`
+
+	f, err := FromJSONSchema([]byte(schema))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.WithRepeaterUrl("addresses", "/repeater")
+
+	repeater, ok := f.GetFields()[0].(*fieldRepeater)
+	if !ok {
+		t.Fatal("Expected a repeater, got:", f.GetFields()[0])
+	}
+	if repeater.GetLabel() != "Addresses" || repeater.repeaterUrl != "/repeater" {
+		t.Fatal("Unexpected repeater:", repeater)
+	}
+	if len(repeater.fields) != 2 || repeater.fields[1].GetName() != "city" || !repeater.fields[1].GetRequired() {
+		t.Fatal("Unexpected repeater fields:", repeater.fields)
+	}
+
+	AssertValidationFailsOn(t, f, map[string]string{"addresses[street][0]": "Main", "addresses[city][0]": "B"}, "addresses[city][0]")
+
+	html := f.Build().ToHTML()
+	if strings.Contains(html, "alert-danger") {
+		t.Fatal("Expected the repeater to render, got:", html)
+	}
+}
+
+func TestFromJSONSchemaUnsupportedKeywords(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"age": {"type": "number", "multipleOf": 5},
+			"host": {"type": "string", "format": "idn-hostname"},
+			"list": {"type": "array", "items": {"type": "string"}},
+			"name": {"type": "string"},
+			"rows": {"type": "array", "items": {"type": "object", "properties": {"a": {"type": "string"}}}}
+		},
+		"required": ["rows"]
+	}`
+
+	f, err := FromJSONSchema([]byte(schema))
+
+	var unsupported *UnsupportedKeywordsError
+	if !errors.As(err, &unsupported) {
+		t.Fatal("Expected UnsupportedKeywordsError, got:", err)
+	}
+
+	expected := []string{"/additionalProperties", "/properties/age/multipleOf", "/properties/host/format", "/properties/list/items", "/required"}
+	if !reflect.DeepEqual(unsupported.Keywords, expected) {
+		t.Fatal("Unexpected keywords:", unsupported.Keywords)
+	}
+	if !strings.Contains(err.Error(), "/properties/age/multipleOf") {
+		t.Fatal("Expected the keywords in the message, got:", err.Error())
+	}
+
+	if f == nil || f.findField("age") == nil || f.findField("host") == nil || f.findField("name") == nil {
+		t.Fatal("Expected the form to be built from the supported keywords")
+	}
+	if f.findField("list") != nil {
+		t.Fatal("Expected the unsupported array to be left out")
+	}
+}
+
+func TestFromJSONSchemaInvalid(t *testing.T) {
+	if _, err := FromJSONSchema([]byte(`{`)); err == nil {
+		t.Fatal("Expected error for invalid JSON")
+	}
+	if _, err := FromJSONSchema([]byte(`{"type": "string"}`)); err == nil {
+		t.Fatal("Expected error for a non-object schema")
+	}
+}
+
+func TestFromJSONSchemaRoundTrip(t *testing.T) {
+	original := New().WithFields(
+		NewStringField("name", "Name").WithRequired().WithValidators(ValidatorMaxLength(20)),
+		NewEmailField("email", "Email"),
+		NewDateTimeField("meeting", "Meeting"),
+		NewDateTimeField("call", "Call").WithValidators(ValidatorPattern(`T(09|1[0-7]):`, "")),
+		NewNumberField("seats", "Seats").WithValidators(ValidatorInteger(), ValidatorMin(1)),
+		NewSelectField("plan", "Plan", []FieldOption{{Key: "free", Value: "Free"}, {Key: "pro", Value: "Pro"}}),
+	)
+
+	data, err := original.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := FromJSONSchema(data, FormOptions{ID: "signup"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roundTrip, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(roundTrip) != string(data) {
+		t.Fatal("Expected the same schema, got:", string(roundTrip), "want:", string(data))
+	}
+}
@@ -0,0 +1,157 @@
+package form
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormJSONSchema(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("name", "Name").WithHelp("Your full name").WithRequired().
+			WithValidators(ValidatorMinLength(2), ValidatorMaxLength(50), ValidatorPattern(`^[A-Za-z ]+$`, "")),
+		NewFieldRow(
+			NewEmailField("email", "Email").WithValidators(ValidatorRequired()),
+			NewNumberField("age", "Age").WithValidators(ValidatorMin(18), ValidatorMax(120)),
+		),
+		NewDateField("birthday", "Birthday"),
+		NewDateTimeField("meeting", "Meeting"),
+		NewURLField("website", "Website"),
+		NewPasswordField("password", "Password"),
+		NewCheckboxField("agree", "Agree"),
+		NewSelectField("country", "Country", []FieldOption{{Key: "de", Value: "Germany"}, {Key: "es", Value: "Spain"}}),
+		NewSelectField("tags", "Tags", []FieldOption{{Key: "a", Value: "A"}}).WithMultiple(),
+		NewSelectField("sizes", "Sizes", nil).WithMultiple().WithValidators(ValidatorOneOf("s", "m"), ValidatorMaxLength(1)),
+		NewStringField("code", "Code").WithReadonly().WithValidators(ValidatorOneOf("x", "y"), ValidatorUUID()),
+		NewRawField("<hr>"),
+		NewFileField("upload", "Upload"),
+	)
+
+	data, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"name": map[string]any{
+				"type": "string", "title": "Name", "description": "Your full name",
+				"minLength": 2.0, "maxLength": 50.0, "pattern": "^[A-Za-z ]+$",
+			},
+			"email":    map[string]any{"type": "string", "title": "Email", "format": "email"},
+			"age":      map[string]any{"type": "number", "title": "Age", "minimum": 18.0, "maximum": 120.0},
+			"birthday": map[string]any{"type": "string", "title": "Birthday", "format": "date"},
+			"meeting":  map[string]any{"type": "string", "title": "Meeting", "pattern": dateTimePattern},
+			"website":  map[string]any{"type": "string", "title": "Website", "format": "uri"},
+			"password": map[string]any{"type": "string", "title": "Password", "writeOnly": true},
+			"agree":    map[string]any{"type": "boolean", "title": "Agree"},
+			"country": map[string]any{
+				"type": "string", "title": "Country",
+				"oneOf": []any{
+					map[string]any{"const": "de", "title": "Germany"},
+					map[string]any{"const": "es", "title": "Spain"},
+				},
+			},
+			"tags": map[string]any{
+				"type": "array", "title": "Tags", "uniqueItems": true,
+				"items": map[string]any{
+					"type":  "string",
+					"oneOf": []any{map[string]any{"const": "a", "title": "A"}},
+				},
+			},
+			"sizes": map[string]any{
+				"type": "array", "title": "Sizes", "uniqueItems": true,
+				"items": map[string]any{"type": "string", "enum": []any{"s", "m"}, "maxLength": 1.0},
+			},
+			"code": map[string]any{
+				"type": "string", "title": "Code", "readOnly": true,
+				"enum": []any{"x", "y"}, "format": "uuid",
+			},
+		},
+		"required": []any{"name", "email"},
+	}
+
+	if !reflect.DeepEqual(schema, expected) {
+		t.Fatal("Unexpected schema:", string(data))
+	}
+}
+
+func TestFormJSONSchemaRepeater(t *testing.T) {
+	f := New().WithFields(
+		NewRepeater(RepeaterOptions{
+			Name:  "addresses",
+			Label: "Addresses",
+			Fields: []FieldInterface{
+				NewStringField("street", "Street").WithRequired(),
+				NewStringField("city", "City"),
+			},
+		}),
+	)
+
+	data, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `"addresses":{"type":"array","title":"Addresses","items":{"type":"object","properties":{"city":{"type":"string","title":"City"},"street":{"type":"string","title":"Street"}},"required":["street"]}}`
+	if !strings.Contains(string(data), expected) {
+		t.Fatal("Expected:", expected, "got:", string(data))
+	}
+}
+
+func TestFormJSONSchemaEmptyOptionKey(t *testing.T) {
+	f := New().WithFields(
+		NewSelectField("size", "Size", []FieldOption{{Key: "", Value: "- choose -"}, {Key: "s", Value: "Small"}}),
+	)
+
+	data, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"oneOf":[{"title":"- choose -","const":""},{"title":"Small","const":"s"}]`) {
+		t.Fatal("Expected empty option key to be kept, got:", string(data))
+	}
+}
+
+func TestFormJSONSchemaCombinesPatterns(t *testing.T) {
+	f := New().WithFields(
+		NewDateTimeField("meeting", "Meeting").WithValidators(ValidatorPattern(`T(09|1[0-7]):`, "Office hours only")),
+		NewStringField("code", "Code").WithValidators(ValidatorPattern(`^[a-z]+$`, ""), ValidatorPattern(`^.{2,5}$`, ""), ValidatorAlphaNumeric()),
+	)
+
+	data, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"meeting": map[string]any{
+			"type": "string", "title": "Meeting", "pattern": dateTimePattern,
+			"allOf": []any{map[string]any{"pattern": `T(09|1[0-7]):`}},
+		},
+		"code": map[string]any{
+			"type": "string", "title": "Code", "pattern": `^[a-z]+$`,
+			"allOf": []any{map[string]any{"pattern": `^.{2,5}$`}, map[string]any{"pattern": alphaNumericPattern}},
+		},
+	}
+
+	if !reflect.DeepEqual(schema.Properties, expected) {
+		t.Fatal("Unexpected schema:", string(data))
+	}
+}
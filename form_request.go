@@ -0,0 +1,140 @@
+package form
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultMaxMemory is the amount of a multipart body kept in memory
+// before the remaining file parts are stored on disk.
+const defaultMaxMemory = 32 << 20 // 32 MB
+
+// ParseRequest reads the submitted values of an *http.Request into the form.
+// Both application/x-www-form-urlencoded and multipart/form-data bodies are supported.
+// It returns the normalized values, keyed by field name, ready to be passed to Validate.
+//
+// Forms protected WithCSRF return ErrInvalidCSRFToken, forms with
+// StrictReject parsing an *UnknownFieldsError, forms with spam protection a
+// *SpamDetected error, and forms with idempotency ErrInvalidSubmissionToken
+// or an *AlreadySubmittedError, without parsing the values, if the request
+// fails their checks.
+func (form *Form) ParseRequest(r *http.Request) (map[string]string, error) {
+	return form.parseRequest(r, true)
+}
+
+// parseRequest parses the request like ParseRequest, checking for spam and
+// claiming the submission token only if submission is set, as repeater
+// actions are not submissions.
+func (form *Form) parseRequest(r *http.Request, submission bool) (map[string]string, error) {
+	if err := parseRequestForm(r); err != nil {
+		return nil, err
+	}
+
+	if err := form.verifyCSRF(r); err != nil {
+		return nil, err
+	}
+
+	if err := form.checkUnknownKeys(r); err != nil {
+		return nil, err
+	}
+
+	if submission {
+		if err := form.verifySpam(r); err != nil {
+			return nil, err
+		}
+		if err := form.claimSubmission(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return form.ParseValues(r.Form), nil
+}
+
+// ParseValues sets the value of each form field from the given submitted values,
+// including the fields nested inside field rows.
+//
+// Values are cleaned up by the fields' sanitizers, and the fields keep the
+// sanitized values. Unchecked checkboxes, which browsers do not submit, get an empty value.
+// Signed fields get the value their signature was made for, or an empty value
+// failing validation if the signature is invalid. Readonly and disabled fields
+// keep their server-side value, whatever was submitted. Submitted keys that
+// are not inputs of the form are ignored; see FilterValues.
+// Multiple selects keep all their selected values in Field.Values, and are
+// a JSON array in the returned map, e.g. ["a","b"]; use GetStrings or Bind
+// to read them. Repeaters get their items rebuilt,
+// which are returned keyed by input name, e.g. "addresses[city][0]".
+func (form *Form) ParseValues(values url.Values) map[string]string {
+	normalized := map[string]string{}
+
+	for _, field := range flattenFields(form.fields) {
+		name := field.GetName()
+		if name == "" {
+			continue
+		}
+
+		switch field.GetType() {
+		case FORM_FIELD_TYPE_RAW, FORM_FIELD_TYPE_FILE, FORM_FIELD_TYPE_TABLE:
+			continue
+		}
+
+		if repeater, ok := field.(*fieldRepeater); ok {
+			repeater.setKeyring(form.keyring)
+			repeater.ParseValues(values)
+			for key, value := range repeater.flattenValues() {
+				normalized[key] = value
+			}
+			continue
+		}
+
+		f, isField := field.(*Field)
+
+		if isField && (f.Readonly || f.Disabled) {
+			normalized[name] = f.Value
+			if f.Multiple {
+				normalized[name] = joinSelected(f.Values)
+			}
+			continue
+		}
+
+		if isField && f.Multiple {
+			selected := submittedValues(values, name)
+			for i := range selected {
+				selected[i] = f.sanitize(selected[i])
+			}
+			f.Values = selected
+			f.Value = joinSelected(selected)
+			normalized[name] = f.Value
+			continue
+		}
+
+		value := values.Get(name)
+		if isField && f.Signed {
+			value = f.openSigned(form.keyring, name, name, value)
+		}
+		if isField {
+			value = f.sanitize(value)
+		}
+		field.SetValue(value)
+		normalized[name] = value
+	}
+
+	return normalized
+}
+
+// submittedValues returns all values submitted under the name,
+// accepting both the "name" and the "name[]" conventions.
+func submittedValues(values url.Values, name string) []string {
+	selected := []string{}
+	selected = append(selected, values[name]...)
+	selected = append(selected, values[name+"[]"]...)
+	return selected
+}
+
+// parseRequestForm parses the request body according to its content type.
+func parseRequestForm(r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.ParseMultipartForm(defaultMaxMemory)
+	}
+	return r.ParseForm()
+}
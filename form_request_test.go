@@ -0,0 +1,149 @@
+package form
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseRequestUrlEncoded(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("name", "Name"),
+		NewEmailField("email", "Email"),
+	)
+
+	body := url.Values{"name": {"John"}, "email": {"john@example.com"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	values, err := f.ParseRequest(r)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if values["name"] != "John" || values["email"] != "john@example.com" {
+		t.Fatal("Unexpected values:", values)
+	}
+
+	html := f.Build().ToHTML()
+	if !strings.Contains(html, `value="John"`) {
+		t.Fatal("Expected parsed value to be rendered, got:", html)
+	}
+}
+
+func TestParseRequestMultipart(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("title", "Title"),
+	)
+
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	if err := writer.WriteField("title", "Hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", buf)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+
+	values, err := f.ParseRequest(r)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if values["title"] != "Hello" {
+		t.Fatal("Expected title to be Hello, got:", values)
+	}
+}
+
+func TestParseRequestInvalidMultipart(t *testing.T) {
+	f := New().WithFields(NewStringField("title", "Title"))
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("garbage"))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+
+	if _, err := f.ParseRequest(r); err == nil {
+		t.Fatal("Expected error for invalid multipart body")
+	}
+}
+
+func TestParseValuesUncheckedCheckbox(t *testing.T) {
+	checkbox := NewCheckboxField("agree", "Agree").WithValue("1")
+	f := New().WithFields(checkbox)
+
+	values := f.ParseValues(url.Values{})
+
+	if v, ok := values["agree"]; !ok || v != "" {
+		t.Fatal("Expected unchecked checkbox to have empty value, got:", values)
+	}
+	if checkbox.Value != "" {
+		t.Fatal("Expected checkbox value to be reset, got:", checkbox.Value)
+	}
+}
+
+func TestParseValuesFieldRowChildren(t *testing.T) {
+	first := NewStringField("first", "First")
+	last := NewStringField("last", "Last")
+	f := New().WithFields(NewFieldRow(first, last))
+
+	values := f.ParseValues(url.Values{"first": {"Jane"}, "last": {"Doe"}})
+
+	if values["first"] != "Jane" || values["last"] != "Doe" {
+		t.Fatal("Unexpected values:", values)
+	}
+	if first.Value != "Jane" || last.Value != "Doe" {
+		t.Fatal("Expected row children to receive values")
+	}
+}
+
+func TestParseValuesMultipleSelect(t *testing.T) {
+	sel := NewSelectField("tags", "Tags", []FieldOption{
+		{Key: "a", Value: "A"},
+		{Key: "b", Value: "B"},
+		{Key: "c", Value: "C"},
+	}).WithMultiple()
+	f := New().WithFields(sel)
+
+	values := f.ParseValues(url.Values{"tags": {"a", "c,d"}})
+
+	if values["tags"] != `["a","c,d"]` {
+		t.Fatal("Expected a JSON array of the values, got:", values["tags"])
+	}
+	if len(sel.Values) != 2 || sel.Values[0] != "a" || sel.Values[1] != "c,d" {
+		t.Fatal("Expected field values [a c,d], got:", sel.Values)
+	}
+
+	f.SetValues(values)
+	if selected, err := f.GetStrings("tags"); err != nil || len(selected) != 2 || selected[1] != "c,d" {
+		t.Fatal("Expected the values to round trip, got:", selected, err)
+	}
+
+	values = f.ParseValues(url.Values{"tags": {"a", "c"}})
+	if len(sel.Values) != 2 || sel.Values[0] != "a" || sel.Values[1] != "c" {
+		t.Fatal("Expected field values [a c], got:", sel.Values)
+	}
+
+	html := f.Build().ToHTML()
+	if strings.Count(html, `selected="selected"`) != 2 {
+		t.Fatal("Expected 2 selected options, got:", html)
+	}
+}
+
+func TestParseValuesSkipsRawAndFile(t *testing.T) {
+	f := New().WithFields(
+		NewRawField("<hr>"),
+		NewFileField("upload", "Upload"),
+	)
+
+	values := f.ParseValues(url.Values{"upload": {"x"}})
+
+	if len(values) != 0 {
+		t.Fatal("Expected no values, got:", values)
+	}
+}
@@ -0,0 +1,396 @@
+package form
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// fieldTypes lists the field types that can be set through the `type` tag option.
+var fieldTypes = []string{
+	FORM_FIELD_TYPE_BLOCKEDITOR,
+	FORM_FIELD_TYPE_CHECKBOX,
+	FORM_FIELD_TYPE_COLOR,
+	FORM_FIELD_TYPE_DATE,
+	FORM_FIELD_TYPE_DATETIME,
+	FORM_FIELD_TYPE_IMAGE,
+	FORM_FIELD_TYPE_HTMLAREA,
+	FORM_FIELD_TYPE_EMAIL,
+	FORM_FIELD_TYPE_FILE,
+	FORM_FIELD_TYPE_HIDDEN,
+	FORM_FIELD_TYPE_NUMBER,
+	FORM_FIELD_TYPE_PASSWORD,
+	FORM_FIELD_TYPE_RADIO,
+	FORM_FIELD_TYPE_SELECT,
+	FORM_FIELD_TYPE_STRING,
+	FORM_FIELD_TYPE_TEL,
+	FORM_FIELD_TYPE_TEXTAREA,
+	FORM_FIELD_TYPE_URL,
+}
+
+// FromStruct creates a form from the exported fields of a struct (or pointer to struct).
+// Each struct field becomes a *Field whose value is the struct field's current value,
+// so forms for editing existing records are prefilled.
+//
+// Fields are configured with the `form` tag, for example:
+//
+//	Email string `form:"email,label=Email,required,type=email,help=We never share it"`
+//	Plan  string `form:"plan,type=select,options=free:Free|pro:Pro"`
+//	Age   int    `form:"age,min=18,max=120"`
+//
+// The first tag part is the field name (the struct field name when empty),
+// "-" skips the field. Supported options are label, type, help, placeholder,
+// options, required, readonly, disabled, multiple, min, max, minlength, maxlength and pattern.
+// Values containing commas are quoted with single quotes, writing a quote
+// inside them twice, e.g. `form:"code,pattern='^[a-z]{2,5}$'"`.
+// When no type is given, it is derived from the Go type.
+//
+// The optional FormOptions configure the form itself; the generated fields
+// are appended after any fields it already contains.
+func FromStruct(v any, opts ...FormOptions) (*Form, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("form: FromStruct requires a non-nil struct")
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("form: FromStruct requires a struct or a pointer to a struct")
+	}
+
+	fields, err := structFields(rv)
+	if err != nil {
+		return nil, err
+	}
+
+	options := FormOptions{}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	form := NewForm(options)
+	form.fields = append(append([]FieldInterface{}, form.fields...), fields...)
+
+	return form, nil
+}
+
+func structFields(rv reflect.Value) ([]FieldInterface, error) {
+	fields := []FieldInterface{}
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		structField := rt.Field(i)
+		fv := rv.Field(i)
+
+		if structField.Anonymous && fv.Kind() == reflect.Struct {
+			embedded, err := structFields(fv)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+
+		if !structField.IsExported() {
+			continue
+		}
+
+		field, err := newFieldFromStructField(structField, fv)
+		if err != nil {
+			return nil, err
+		}
+
+		if field != nil {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+// newFieldFromStructField creates a field from a struct field and its `form` tag.
+// It returns nil for fields tagged with "-".
+func newFieldFromStructField(structField reflect.StructField, fv reflect.Value) (*Field, error) {
+	tag := structField.Tag.Get("form")
+	name := tagName(tag)
+
+	if name == "-" {
+		return nil, nil
+	}
+
+	if name == "" {
+		name = structField.Name
+	}
+
+	if !isSupportedStructField(fv) {
+		return nil, errors.New("form: field " + structField.Name + ": unsupported type " + fv.Type().String())
+	}
+
+	field := &Field{
+		Name:  name,
+		Label: structField.Name,
+		Type:  structFieldType(fv),
+	}
+
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.String {
+		field.Multiple = true
+	}
+
+	_, options, _ := strings.Cut(tag, ",")
+	if err := applyTagOptions(field, options); err != nil {
+		return nil, errors.New("form: field " + structField.Name + ": " + err.Error())
+	}
+
+	if fv.Kind() == reflect.Slice {
+		field.Values = make([]string, fv.Len())
+		for j := 0; j < fv.Len(); j++ {
+			field.Values[j] = fv.Index(j).String()
+		}
+		field.Value = joinSelected(field.Values)
+	} else {
+		field.Value = structFieldValue(field.Type, fv)
+	}
+
+	return field, nil
+}
+
+// applyTagOptions applies the comma separated `form` tag options to the field.
+func applyTagOptions(field *Field, options string) error {
+	if options == "" {
+		return nil
+	}
+
+	parsed, err := splitTagOptions(options)
+	if err != nil {
+		return err
+	}
+
+	for _, option := range parsed {
+		key, value := option.key, option.value
+
+		switch key {
+		case "":
+			continue
+		case "label":
+			field.Label = value
+		case "help":
+			field.Help = value
+		case "placeholder":
+			field.Placeholder = value
+		case "type":
+			if !lo.Contains(fieldTypes, value) {
+				return errors.New("unknown field type " + value)
+			}
+			field.Type = value
+		case "options":
+			field.Options = parseTagOptions(value)
+		case "required":
+			field.Required = true
+		case "readonly":
+			field.Readonly = true
+		case "disabled":
+			field.Disabled = true
+		case "multiple":
+			field.Multiple = true
+		case "minlength", "maxlength":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.New(key + " must be a whole number")
+			}
+			if key == "minlength" {
+				field.Validators = append(field.Validators, ValidatorMinLength(n))
+			} else {
+				field.Validators = append(field.Validators, ValidatorMaxLength(n))
+			}
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return errors.New(key + " must be a number")
+			}
+			if key == "min" {
+				field.Validators = append(field.Validators, ValidatorMin(n))
+			} else {
+				field.Validators = append(field.Validators, ValidatorMax(n))
+			}
+		case "pattern":
+			if _, err := regexp.Compile(value); err != nil {
+				return errors.New("invalid pattern: " + err.Error())
+			}
+			field.Validators = append(field.Validators, ValidatorPattern(value, ""))
+		default:
+			return errors.New("unknown tag option " + key)
+		}
+	}
+
+	switch field.Type {
+	case FORM_FIELD_TYPE_EMAIL:
+		field.Validators = append(field.Validators, ValidatorEmail())
+	case FORM_FIELD_TYPE_URL:
+		field.Validators = append(field.Validators, ValidatorURL())
+	}
+
+	return nil
+}
+
+// tagOption is a "key=value" or "key" option of a `form` tag.
+type tagOption struct {
+	key   string
+	value string
+}
+
+// splitTagOptions splits comma separated `form` tag options. A value quoted
+// with single quotes may contain commas, e.g. pattern='^[a-z]{2,5}$', and
+// a quote inside it is written twice.
+func splitTagOptions(options string) ([]tagOption, error) {
+	parsed := []tagOption{}
+	rest := options
+
+	for rest != "" {
+		option := tagOption{}
+		end := strings.IndexAny(rest, ",=")
+
+		switch {
+		case end < 0:
+			option.key, rest = rest, ""
+		case rest[end] == ',':
+			option.key, rest = rest[:end], rest[end+1:]
+		default:
+			option.key, rest = rest[:end], rest[end+1:]
+			if !strings.HasPrefix(rest, "'") {
+				option.value, rest, _ = strings.Cut(rest, ",")
+				break
+			}
+
+			value, remaining, err := unquoteTagValue(rest)
+			if err != nil {
+				return nil, errors.New(strings.TrimSpace(option.key) + ": " + err.Error())
+			}
+			if remaining != "" && remaining[0] != ',' {
+				return nil, errors.New(strings.TrimSpace(option.key) + ": unexpected text after the quoted value")
+			}
+			option.value, rest = value, strings.TrimPrefix(remaining, ",")
+		}
+
+		option.key = strings.TrimSpace(option.key)
+		parsed = append(parsed, option)
+	}
+
+	return parsed, nil
+}
+
+// unquoteTagValue returns the value quoted with single quotes at the start
+// of s, and the rest of s after the closing quote.
+func unquoteTagValue(s string) (string, string, error) {
+	value := strings.Builder{}
+
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			value.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '\'' {
+			value.WriteByte('\'')
+			i++
+			continue
+		}
+		return value.String(), s[i+1:], nil
+	}
+
+	return "", "", errors.New("unterminated quote")
+}
+
+// parseTagOptions parses options in the "key:Value|key:Value" format.
+// A key without a value is also used as the value.
+func parseTagOptions(value string) []FieldOption {
+	options := []FieldOption{}
+	for _, pair := range strings.Split(value, "|") {
+		if pair == "" {
+			continue
+		}
+		key, label, found := strings.Cut(pair, ":")
+		if !found {
+			label = key
+		}
+		options = append(options, FieldOption{Key: key, Value: label})
+	}
+	return options
+}
+
+// structFieldType derives the field type from the Go type of a struct field.
+func structFieldType(fv reflect.Value) string {
+	if fv.Type() == timeType {
+		return FORM_FIELD_TYPE_DATE
+	}
+
+	switch fv.Kind() {
+	case reflect.Bool:
+		return FORM_FIELD_TYPE_CHECKBOX
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return FORM_FIELD_TYPE_NUMBER
+	case reflect.Slice:
+		return FORM_FIELD_TYPE_SELECT
+	}
+
+	return FORM_FIELD_TYPE_STRING
+}
+
+// structFieldValue formats the value of a struct field as a form value.
+func structFieldValue(fieldType string, fv reflect.Value) string {
+	if fv.Type() == timeType {
+		t := fv.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		if fieldType == FORM_FIELD_TYPE_DATETIME {
+			return t.Format(dateTimeLayout)
+		}
+		return t.Format(dateLayout)
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String()
+	case reflect.Bool:
+		if fv.Bool() {
+			return "1"
+		}
+		return ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits())
+	}
+
+	return ""
+}
+
+// isSupportedStructField reports whether the Go type of a struct field can be
+// represented by a form field.
+func isSupportedStructField(fv reflect.Value) bool {
+	if fv.Type() == timeType {
+		return true
+	}
+
+	switch fv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return fv.Type().Elem().Kind() == reflect.String
+	}
+
+	return false
+}
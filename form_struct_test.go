@@ -0,0 +1,178 @@
+package form
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type profileBase struct {
+	ID string `form:"id,type=hidden"`
+}
+
+type profile struct {
+	profileBase
+	Email    string    `form:"email,label=Email,required,type=email,help=We never share it"`
+	Name     string    `form:"name,label=Full Name,placeholder=Jane Doe,minlength=2,maxlength=50"`
+	Age      int       `form:"age,min=18,max=120"`
+	Plan     string    `form:"plan,type=select,options=free:Free|pro:Pro"`
+	Tags     []string  `form:"tags,options=a|b"`
+	Active   bool      `form:"active"`
+	Birthday time.Time `form:"birthday"`
+	Notes    string
+	Secret   string `form:"-"`
+	internal string
+}
+
+func TestFromStruct(t *testing.T) {
+	f, err := FromStruct(&profile{
+		profileBase: profileBase{ID: "42"},
+		Email:       "jane@example.com",
+		Name:        "Jane",
+		Age:         30,
+		Plan:        "pro",
+		Tags:        []string{"b"},
+		Active:      true,
+		Birthday:    time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC),
+	}, FormOptions{ID: "profileForm"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	fields := f.GetFields()
+	if len(fields) != 9 {
+		t.Fatal("Expected 9 fields, got:", len(fields))
+	}
+
+	email := f.findField("email").(*Field)
+	if email.Type != FORM_FIELD_TYPE_EMAIL || !email.Required || email.Label != "Email" || email.Help != "We never share it" {
+		t.Fatal("Unexpected email field:", email)
+	}
+	if email.Value != "jane@example.com" {
+		t.Fatal("Expected email to be prefilled, got:", email.Value)
+	}
+	if len(email.Validators) != 1 {
+		t.Fatal("Expected email validator, got:", len(email.Validators))
+	}
+
+	name := f.findField("name").(*Field)
+	if name.Placeholder != "Jane Doe" || len(name.Validators) != 2 {
+		t.Fatal("Unexpected name field:", name)
+	}
+
+	age := f.findField("age").(*Field)
+	if age.Type != FORM_FIELD_TYPE_NUMBER || age.Value != "30" || len(age.Validators) != 2 {
+		t.Fatal("Unexpected age field:", age)
+	}
+
+	plan := f.findField("plan").(*Field)
+	if plan.Type != FORM_FIELD_TYPE_SELECT || len(plan.Options) != 2 || plan.Options[1].Value != "Pro" {
+		t.Fatal("Unexpected plan field:", plan)
+	}
+
+	tags := f.findField("tags").(*Field)
+	if !tags.Multiple || len(tags.Values) != 1 || tags.Values[0] != "b" || tags.Options[0].Value != "a" {
+		t.Fatal("Unexpected tags field:", tags)
+	}
+
+	active := f.findField("active").(*Field)
+	if active.Type != FORM_FIELD_TYPE_CHECKBOX || active.Value != "1" {
+		t.Fatal("Unexpected active field:", active)
+	}
+
+	birthday := f.findField("birthday").(*Field)
+	if birthday.Type != FORM_FIELD_TYPE_DATE || birthday.Value != "1990-05-17" {
+		t.Fatal("Unexpected birthday field:", birthday)
+	}
+
+	if f.findField("Notes") == nil {
+		t.Fatal("Expected untagged field to use the struct field name")
+	}
+	if f.findField("Secret") != nil || f.findField("internal") != nil {
+		t.Fatal("Expected skipped and unexported fields to be ignored")
+	}
+
+	html := f.Build().ToHTML()
+	for _, expected := range []string{`id="profileForm"`, `name="id" type="hidden" value="42"`, `value="jane@example.com"`} {
+		if !strings.Contains(html, expected) {
+			t.Fatal("Expected:", expected, "got:", html)
+		}
+	}
+}
+
+func TestFromStructValidates(t *testing.T) {
+	f, err := FromStruct(profile{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	AssertValidationFailsOn(t, f, map[string]string{"email": "", "age": "30"}, "email")
+	AssertValidationFailsOn(t, f, map[string]string{"email": "a@b.co", "age": "12"}, "age")
+}
+
+func TestFromStructQuotedTagValues(t *testing.T) {
+	f, err := FromStruct(struct {
+		Code string `form:"code,pattern='^[a-z]{2,5}$',help='Two, three or five letters',label='Owner''s code',required"`
+	}{})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	code := f.findField("code").(*Field)
+	if code.Help != "Two, three or five letters" || code.Label != "Owner's code" || !code.Required {
+		t.Fatal("Unexpected code field:", code)
+	}
+	if len(code.Validators) != 1 || code.Validators[0].Params()["pattern"] != "^[a-z]{2,5}$" {
+		t.Fatal("Expected the whole pattern, got:", code.Validators)
+	}
+
+	if errs := f.Validate(map[string]string{"code": "abcdef"}); len(errs) != 1 || errs[0].Rule != RulePattern {
+		t.Fatal("Expected a pattern error, got:", errs)
+	}
+	if errs := f.Validate(map[string]string{"code": "abc"}); len(errs) != 0 {
+		t.Fatal("Expected no errors, got:", errs)
+	}
+
+	for _, tag := range []string{`code,pattern='^[a-z]{2,5}$`, `code,label='Code'x`} {
+		field := reflect.StructField{Name: "Code", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`form:"` + tag + `"`)}
+		if _, err := newFieldFromStructField(field, reflect.ValueOf("")); err == nil {
+			t.Error("Expected an error for", tag)
+		}
+	}
+}
+
+func TestFromStructErrors(t *testing.T) {
+	if _, err := FromStruct("not a struct"); err == nil {
+		t.Fatal("Expected error for non-struct")
+	}
+
+	var nilProfile *profile
+	if _, err := FromStruct(nilProfile); err == nil {
+		t.Fatal("Expected error for nil pointer")
+	}
+
+	if _, err := FromStruct(struct {
+		Kind string `form:"kind,type=bogus"`
+	}{}); err == nil {
+		t.Fatal("Expected error for unknown type")
+	}
+
+	if _, err := FromStruct(struct {
+		Kind string `form:"kind,unknown"`
+	}{}); err == nil {
+		t.Fatal("Expected error for unknown option")
+	}
+
+	if _, err := FromStruct(struct {
+		Code string `form:"code,pattern=[a-z"`
+	}{}); err == nil {
+		t.Fatal("Expected error for an invalid pattern")
+	}
+
+	if _, err := FromStruct(struct {
+		Meta map[string]string `form:"meta"`
+	}{}); err == nil {
+		t.Fatal("Expected error for unsupported type")
+	}
+}
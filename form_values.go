@@ -0,0 +1,262 @@
+package form
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetValues sets the value of each form field found in the values, keyed by
+// field name, in the shape returned by ParseValues: multiple selects are
+// JSON arrays and repeater items are keyed by input name, e.g.
+// "addresses[city][0]". Fields missing from the values keep their value.
+func (form *Form) SetValues(values map[string]string) {
+	for _, field := range flattenFields(form.fields) {
+		name := field.GetName()
+		if name == "" {
+			continue
+		}
+
+		if repeater, ok := field.(*fieldRepeater); ok {
+			itemValues := url.Values{}
+			for key, value := range values {
+				if strings.HasPrefix(key, name+`[`) {
+					itemValues.Set(key, value)
+				}
+			}
+			if len(itemValues) > 0 {
+				repeater.SetValues(ParseRepeaterFormValues(itemValues, name))
+			}
+			continue
+		}
+
+		value, exists := values[name]
+		if !exists {
+			continue
+		}
+
+		if f, ok := field.(*Field); ok && f.Multiple {
+			f.Values = splitSelected(value)
+		}
+
+		field.SetValue(value)
+	}
+}
+
+// GetString returns the value of the named field. Fields of repeater items
+// are named by their input names, e.g. "addresses[city][0]".
+func (form *Form) GetString(name string) (string, error) {
+	_, value, err := form.lookupValue(name)
+	return value, err
+}
+
+// GetInt returns the value of the named field as an int, 0 if empty.
+func (form *Form) GetInt(name string) (int, error) {
+	field, value, err := form.lookupValue(name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, form.valueError(field, name, RuleInteger, nil)
+	}
+
+	return n, nil
+}
+
+// GetFloat returns the value of the named field as a float64, 0 if empty.
+func (form *Form) GetFloat(name string) (float64, error) {
+	field, value, err := form.lookupValue(name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, form.valueError(field, name, RuleNumber, nil)
+	}
+
+	return n, nil
+}
+
+// GetBool reports whether the named field has a value that checks a
+// checkbox: "1", "true", "on" or "yes".
+func (form *Form) GetBool(name string) (bool, error) {
+	_, value, err := form.lookupValue(name)
+	if err != nil {
+		return false, err
+	}
+
+	return isTruthy(value), nil
+}
+
+// GetTime returns the value of the named field as a time in UTC, parsed with
+// the layout submitted by its input: "2006-01-02" for date fields and
+// "2006-01-02T15:04", with optional seconds, for datetime fields.
+// An empty value returns the zero time.
+func (form *Form) GetTime(name string) (time.Time, error) {
+	field, value, err := form.lookupValue(name)
+	if err != nil || value == "" {
+		return time.Time{}, err
+	}
+
+	t, err := parseFieldTime(field.GetType(), value)
+	if err != nil {
+		return time.Time{}, form.valueError(field, name, RuleDate, nil)
+	}
+
+	return t, nil
+}
+
+// GetStrings returns the selected values of a multiple select, or the single
+// non-empty value of other fields.
+func (form *Form) GetStrings(name string) ([]string, error) {
+	field, value, err := form.lookupValue(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if field.GetName() == name {
+		return fieldValues(field), nil
+	}
+
+	if value == "" {
+		return []string{}, nil
+	}
+
+	return []string{value}, nil
+}
+
+// GetDecimal returns the decimal value of the named field in minor units,
+// e.g. 1250 for "12.5" with a scale of 2, without the rounding errors of
+// floats. Values with more decimal places than the scale, other than
+// trailing zeros, are rejected. An empty value returns 0.
+func (form *Form) GetDecimal(name string, scale int) (int64, error) {
+	field, value, err := form.lookupValue(name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	n, rule := parseMinorUnits(strings.TrimSpace(value), scale)
+	if rule != "" {
+		return 0, form.valueError(field, name, rule, map[string]string{"scale": strconv.Itoa(scale)})
+	}
+
+	return n, nil
+}
+
+// lookupValue returns the named field and its value. Names of the fields of
+// repeater items, e.g. "addresses[city][0]", return the item field and the
+// item's value.
+func (form *Form) lookupValue(name string) (FieldInterface, string, error) {
+	if field := form.findField(name); field != nil {
+		return field, field.GetValue(), nil
+	}
+
+	for _, field := range flattenFields(form.fields) {
+		repeater, ok := field.(*fieldRepeater)
+		if !ok || !strings.HasPrefix(name, repeater.GetName()+`[`) {
+			continue
+		}
+
+		fieldName, index, ok := splitRepeaterKey(name[len(repeater.GetName())+1:])
+		if !ok || index < 0 {
+			continue
+		}
+
+		for _, itemField := range flattenFields(repeater.fields) {
+			if itemField.GetName() != fieldName {
+				continue
+			}
+			value := ""
+			if index < len(repeater.values) {
+				value = repeater.values[index][fieldName]
+			}
+			return itemField, value, nil
+		}
+	}
+
+	return nil, "", errors.New("form: field " + name + " not found")
+}
+
+// valueError returns the error of a value that cannot be converted, as
+// ValidationErrors with the message in the form's locale.
+func (form *Form) valueError(field FieldInterface, name string, rule string, params map[string]string) error {
+	err := ruleError(name, rule, params)
+	form.localize(err, field)
+	return ValidationErrors{*err}
+}
+
+// parseMinorUnits parses a decimal number into minor units of the scale.
+// It returns the name of the failed rule if the value is invalid.
+func parseMinorUnits(value string, scale int) (int64, string) {
+	scale = max(scale, 0)
+
+	negative := strings.HasPrefix(value, "-")
+	unsigned := strings.TrimLeft(value, "+-")
+	if len(value)-len(unsigned) > 1 {
+		return 0, RuleNumber
+	}
+
+	whole, fraction, _ := strings.Cut(unsigned, ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, RuleNumber
+	}
+
+	if len(fraction) > scale {
+		if strings.Trim(fraction[scale:], "0") != "" {
+			return 0, RuleDecimal
+		}
+		fraction = fraction[:scale]
+	}
+	fraction += strings.Repeat("0", scale-len(fraction))
+
+	n, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, RuleNumber
+	}
+
+	if negative {
+		n = -n
+	}
+
+	return n, ""
+}
+
+// isDigits reports whether the value contains only the digits 0-9.
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// joinSelected returns the values selected in a multiple select as a JSON
+// array, the value of the field in the map returned by ParseValues, or ""
+// if none are selected.
+func joinSelected(selected []string) string {
+	if len(selected) == 0 {
+		return ""
+	}
+	return listParam(selected)
+}
+
+// splitSelected returns the values selected in a multiple select from a
+// value returned by joinSelected. Any other non-empty value is a single
+// selected value.
+func splitSelected(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	if strings.HasPrefix(value, "[") {
+		if selected, err := parseListParam(value); err == nil {
+			return selected
+		}
+	}
+	return []string{value}
+}
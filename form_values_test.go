@@ -0,0 +1,183 @@
+package form
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func valuesTestForm() *Form {
+	return New().WithFields(
+		NewStringField("name", "Name"),
+		NewFieldRow(
+			NewNumberField("qty", "Quantity"),
+			NewNumberField("weight", "Weight"),
+		),
+		NewStringField("price", "Price"),
+		NewCheckboxField("agree", "Agree"),
+		NewDateField("birthday", "Birthday"),
+		NewDateTimeField("meeting", "Meeting"),
+		NewSelectField("sizes", "Sizes", nil).WithMultiple(),
+		NewRepeater(RepeaterOptions{
+			Name:   "items",
+			Fields: []FieldInterface{NewNumberField("qty", "Quantity")},
+		}),
+	)
+}
+
+func TestFormTypedAccessorsAfterParseRequest(t *testing.T) {
+	body := "name=Jane&qty=3&weight=2.5&price=12.5&agree=on&birthday=1990-05-17" +
+		"&meeting=2024-03-01T09:30&sizes=s&sizes=m&items[qty][0]=4&items[qty][1]=7"
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	f := valuesTestForm()
+	if _, err := f.ParseRequest(r); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, err := f.GetString("name"); err != nil || name != "Jane" {
+		t.Fatal("Unexpected name:", name, err)
+	}
+
+	if qty, err := f.GetInt("qty"); err != nil || qty != 3 {
+		t.Fatal("Unexpected qty:", qty, err)
+	}
+
+	if weight, err := f.GetFloat("weight"); err != nil || weight != 2.5 {
+		t.Fatal("Unexpected weight:", weight, err)
+	}
+
+	if price, err := f.GetDecimal("price", 2); err != nil || price != 1250 {
+		t.Fatal("Unexpected price:", price, err)
+	}
+
+	if agree, err := f.GetBool("agree"); err != nil || !agree {
+		t.Fatal("Unexpected agree:", agree, err)
+	}
+
+	if birthday, err := f.GetTime("birthday"); err != nil || !birthday.Equal(time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal("Unexpected birthday:", birthday, err)
+	}
+
+	if meeting, err := f.GetTime("meeting"); err != nil || !meeting.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Fatal("Unexpected meeting:", meeting, err)
+	}
+
+	if sizes, err := f.GetStrings("sizes"); err != nil || !reflect.DeepEqual(sizes, []string{"s", "m"}) {
+		t.Fatal("Unexpected sizes:", sizes, err)
+	}
+
+	if qty, err := f.GetInt("items[qty][1]"); err != nil || qty != 7 {
+		t.Fatal("Unexpected item qty:", qty, err)
+	}
+
+	if qty, err := f.GetStrings("items[qty][0]"); err != nil || !reflect.DeepEqual(qty, []string{"4"}) {
+		t.Fatal("Unexpected item qty:", qty, err)
+	}
+}
+
+func TestFormTypedAccessorsAfterSetValues(t *testing.T) {
+	f := valuesTestForm()
+	f.SetValues(map[string]string{
+		"qty":           "2",
+		"sizes":         `["l","xl"]`,
+		"agree":         "",
+		"items[qty][0]": "9",
+	})
+
+	if qty, err := f.GetInt("qty"); err != nil || qty != 2 {
+		t.Fatal("Unexpected qty:", qty, err)
+	}
+
+	if sizes, err := f.GetStrings("sizes"); err != nil || !reflect.DeepEqual(sizes, []string{"l", "xl"}) {
+		t.Fatal("Unexpected sizes:", sizes, err)
+	}
+
+	if agree, err := f.GetBool("agree"); err != nil || agree {
+		t.Fatal("Unexpected agree:", agree, err)
+	}
+
+	if qty, err := f.GetInt("items[qty][0]"); err != nil || qty != 9 {
+		t.Fatal("Unexpected item qty:", qty, err)
+	}
+
+	// Empty values are the zero value
+	if weight, err := f.GetFloat("weight"); err != nil || weight != 0 {
+		t.Fatal("Unexpected weight:", weight, err)
+	}
+	if birthday, err := f.GetTime("birthday"); err != nil || !birthday.IsZero() {
+		t.Fatal("Unexpected birthday:", birthday, err)
+	}
+}
+
+func TestFormTypedAccessorErrors(t *testing.T) {
+	f := valuesTestForm().WithLocale("de")
+	f.SetValues(map[string]string{
+		"qty":      "three",
+		"weight":   "heavy",
+		"price":    "12.345",
+		"birthday": "17.05.1990",
+	})
+
+	if _, err := f.GetString("missing"); err == nil || err.Error() != "form: field missing not found" {
+		t.Fatal("Expected a not found error, got:", err)
+	}
+
+	if _, err := f.GetInt("items[qty][5]"); err != nil {
+		t.Fatal("Expected a missing item to be empty, got:", err)
+	}
+
+	tests := []struct {
+		get     func() error
+		rule    string
+		message string
+	}{
+		{func() error { _, err := f.GetInt("qty"); return err }, RuleInteger, "Quantity muss eine ganze Zahl sein"},
+		{func() error { _, err := f.GetFloat("weight"); return err }, RuleNumber, "Weight muss eine gültige Zahl sein"},
+		{func() error { _, err := f.GetDecimal("price", 2); return err }, RuleDecimal, "Price darf höchstens 2 Nachkommastellen haben"},
+		{func() error { _, err := f.GetTime("birthday"); return err }, RuleDate, "Birthday muss ein gültiges Datum sein"},
+	}
+
+	for _, test := range tests {
+		var errs ValidationErrors
+		if err := test.get(); !errors.As(err, &errs) || errs[0].Rule != test.rule || errs[0].Message != test.message {
+			t.Errorf("Expected a %s error %q, got: %v", test.rule, test.message, err)
+		}
+	}
+}
+
+func TestParseMinorUnits(t *testing.T) {
+	tests := []struct {
+		value    string
+		scale    int
+		expected int64
+		rule     string
+	}{
+		{"12.5", 2, 1250, ""},
+		{"12.50", 2, 1250, ""},
+		{"12.500", 2, 1250, ""},
+		{"-0.01", 2, -1, ""},
+		{"+3", 2, 300, ""},
+		{".5", 2, 50, ""},
+		{"7.", 0, 7, ""},
+		{"0.1", 0, 0, RuleDecimal},
+		{"12.345", 2, 0, RuleDecimal},
+		{"1e3", 2, 0, RuleNumber},
+		{"--1", 2, 0, RuleNumber},
+		{"1.2.3", 2, 0, RuleNumber},
+		{".", 2, 0, RuleNumber},
+		{"99999999999999999999", 2, 0, RuleNumber},
+	}
+
+	for _, test := range tests {
+		n, rule := parseMinorUnits(test.value, test.scale)
+		if n != test.expected || rule != test.rule {
+			t.Errorf("%q scale %d: expected %d %q, got %d %q", test.value, test.scale, test.expected, test.rule, n, rule)
+		}
+	}
+}
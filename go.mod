@@ -6,6 +6,7 @@ require (
 	github.com/dracory/hb v1.88.0
 	github.com/dracory/uid v1.9.0
 	github.com/samber/lo v1.52.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require github.com/rogpeppe/go-internal v1.14.1 // indirect
@@ -13,5 +14,5 @@ require github.com/rogpeppe/go-internal v1.14.1 // indirect
 require (
 	github.com/spf13/cast v1.10.0
 	golang.org/x/net v0.50.0 // indirect
-	golang.org/x/text v0.34.0 // indirect
+	golang.org/x/text v0.34.0
 )
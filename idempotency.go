@@ -0,0 +1,310 @@
+package form
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/dracory/hb"
+)
+
+// IdempotencyFieldName is the name of the hidden input holding the one-time
+// submission token of a form with idempotency.
+const IdempotencyFieldName = "submission_token"
+
+// ErrAlreadySubmitted is matched, with errors.Is, by the error ParseRequest
+// returns when a form with idempotency receives a submission token that was
+// already used, e.g. after a double click or a browser retry.
+var ErrAlreadySubmitted = errors.New("form: already submitted")
+
+// ErrInvalidSubmissionToken is returned by ParseRequest when a form with
+// idempotency receives no submission token, or one it did not render, e.g.
+// one that was made up or tampered with.
+var ErrInvalidSubmissionToken = errors.New("form: missing or invalid submission token")
+
+// errIdempotencyKeyring is returned by ParseRequest for a form with
+// idempotency but without a keyring to sign the submission tokens.
+var errIdempotencyKeyring = errors.New("form: idempotency needs a keyring, see WithKeyring")
+
+// maxSubmissionTokenLength is the longest submission token that is read.
+// Rendered tokens are the key ID and a dot followed by 66 bytes.
+const maxSubmissionTokenLength = 256
+
+// AlreadySubmittedError is returned by ParseRequest for a submission token
+// that was already used. Response is the response saved for the first
+// submission with SaveResponse, or nil if none was saved, e.g. because the
+// first submission is still being processed:
+//
+//	var submitted *form.AlreadySubmittedError
+//	if errors.As(err, &submitted) && submitted.Response != nil {
+//	    submitted.Response.Write(w)
+//	    return
+//	}
+type AlreadySubmittedError struct {
+	Response *CachedResponse
+}
+
+// Error returns the message of ErrAlreadySubmitted.
+func (err *AlreadySubmittedError) Error() string {
+	return ErrAlreadySubmitted.Error()
+}
+
+// Is reports whether the target is ErrAlreadySubmitted.
+func (err *AlreadySubmittedError) Is(target error) bool {
+	return target == ErrAlreadySubmitted
+}
+
+// CachedResponse is the response to a submission, saved so that repeated
+// submissions get the same response without being processed again.
+type CachedResponse struct {
+	Status int // HTTP status code, 200 if zero
+	Header http.Header
+	Body   []byte
+}
+
+// Write writes the cached response to w.
+func (response *CachedResponse) Write(w http.ResponseWriter) {
+	for key, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(response.Body)
+}
+
+// IdempotencyStore remembers the submission tokens that were used, and the
+// responses saved for them. It must be shared by all servers that receive
+// the form's submissions, e.g. backed by Redis with SET NX and an expiry.
+type IdempotencyStore interface {
+	// Claim marks the token as used. It reports false if the token was
+	// already used, and must be atomic, so only one of two concurrent
+	// claims of a token succeeds.
+	Claim(ctx context.Context, token string) (bool, error)
+
+	// SaveResponse saves the response to the submission that claimed the token.
+	SaveResponse(ctx context.Context, token string, response CachedResponse) error
+
+	// Response returns the response saved for the token, if any.
+	Response(ctx context.Context, token string) (*CachedResponse, error)
+
+	// Release forgets the token, so it can be claimed again.
+	Release(ctx context.Context, token string) error
+}
+
+// MemoryIdempotencyStoreOptions configures a new in-memory idempotency store.
+type MemoryIdempotencyStoreOptions struct {
+	// TTL is how long used tokens and their responses are remembered,
+	// 24 hours by default. Use at least the time a rendered form can be
+	// submitted, as a forgotten token can be submitted again.
+	TTL time.Duration
+
+	// Now returns the current time, time.Now by default.
+	Now func() time.Time
+}
+
+// memoryIdempotencyStore is an IdempotencyStore keeping the tokens in a map,
+// removing the expired ones as new tokens are claimed.
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryIdempotencyEntry
+	ttl       time.Duration
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+type memoryIdempotencyEntry struct {
+	expires  time.Time
+	response *CachedResponse
+}
+
+var _ IdempotencyStore = (*memoryIdempotencyStore)(nil)
+
+// NewMemoryIdempotencyStore creates an IdempotencyStore keeping the tokens
+// in memory. It suits a single server; its tokens are lost on restart.
+// Create it once and share it by all requests:
+//
+//	var submissions = form.NewMemoryIdempotencyStore(form.MemoryIdempotencyStoreOptions{})
+func NewMemoryIdempotencyStore(opts MemoryIdempotencyStoreOptions) IdempotencyStore {
+	store := &memoryIdempotencyStore{
+		entries: map[string]memoryIdempotencyEntry{},
+		ttl:     opts.TTL,
+		now:     opts.Now,
+	}
+	if store.ttl <= 0 {
+		store.ttl = 24 * time.Hour
+	}
+	if store.now == nil {
+		store.now = time.Now
+	}
+	return store
+}
+
+// Claim marks the token as used until the TTL has passed.
+func (store *memoryIdempotencyStore) Claim(_ context.Context, token string) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	if entry, found := store.entries[token]; found && now.Before(entry.expires) {
+		return false, nil
+	}
+
+	store.entries[token] = memoryIdempotencyEntry{expires: now.Add(store.ttl)}
+	return true, nil
+}
+
+// SaveResponse saves the response of a claimed token, ignoring expired tokens.
+func (store *memoryIdempotencyStore) SaveResponse(_ context.Context, token string, response CachedResponse) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entry, found := store.entries[token]
+	if !found || !store.now().Before(entry.expires) {
+		return nil
+	}
+
+	entry.response = &response
+	store.entries[token] = entry
+	return nil
+}
+
+// Response returns the response saved for an unexpired token.
+func (store *memoryIdempotencyStore) Response(_ context.Context, token string) (*CachedResponse, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	entry, found := store.entries[token]
+	if !found || !store.now().Before(entry.expires) {
+		return nil, nil
+	}
+	return entry.response, nil
+}
+
+// Release forgets the token and its response.
+func (store *memoryIdempotencyStore) Release(_ context.Context, token string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	delete(store.entries, token)
+	return nil
+}
+
+// sweep removes the expired tokens, at most once per minute.
+func (store *memoryIdempotencyStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < time.Minute {
+		return
+	}
+	store.lastSweep = now
+
+	for token, entry := range store.entries {
+		if !now.Before(entry.expires) {
+			delete(store.entries, token)
+		}
+	}
+}
+
+// WithIdempotency protects the form against double submits. Build renders
+// a new one-time token, signed with the form's keyring, as a hidden input,
+// and ParseRequest claims it in the store, returning an *AlreadySubmittedError
+// if it was already used, and ErrInvalidSubmissionToken if it is missing.
+//
+// The form needs a keyring, see WithKeyring. Without one, Build renders an
+// error message instead of the token and ParseRequest returns an error.
+func (form *Form) WithIdempotency(store IdempotencyStore) *Form {
+	form.idempotency = store
+	return form
+}
+
+// SaveResponse saves the response to the submission parsed by the last
+// ParseRequest, returned to the submissions repeating its token. It does
+// nothing if the form has no idempotency or parsed no submission.
+func (form *Form) SaveResponse(ctx context.Context, response CachedResponse) error {
+	if form.idempotency == nil || form.submissionToken == "" {
+		return nil
+	}
+	return form.idempotency.SaveResponse(ctx, form.submissionToken, response)
+}
+
+// ReleaseSubmission forgets the submission token claimed by the last
+// ParseRequest, so the submission can be sent again, e.g. when processing it
+// failed. It does nothing if the form has no idempotency or parsed no
+// submission.
+func (form *Form) ReleaseSubmission(ctx context.Context) error {
+	if form.idempotency == nil || form.submissionToken == "" {
+		return nil
+	}
+
+	token := form.submissionToken
+	form.submissionToken = ""
+	return form.idempotency.Release(ctx, token)
+}
+
+// claimSubmission claims the submission token of a parsed request.
+func (form *Form) claimSubmission(r *http.Request) error {
+	form.submissionToken = ""
+	if form.idempotency == nil {
+		return nil
+	}
+
+	if form.keyring == nil {
+		return errIdempotencyKeyring
+	}
+
+	token := r.Form.Get(IdempotencyFieldName)
+	if !form.isSubmissionToken(token) {
+		return ErrInvalidSubmissionToken
+	}
+
+	claimed, err := form.idempotency.Claim(r.Context(), token)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		response, err := form.idempotency.Response(r.Context(), token)
+		if err != nil {
+			return err
+		}
+		return &AlreadySubmittedError{Response: response}
+	}
+
+	form.submissionToken = token
+	return nil
+}
+
+// isSubmissionToken reports whether the token was rendered by a form with
+// the same keyring, so only rendered tokens take up room in the store.
+func (form *Form) isSubmissionToken(token string) bool {
+	if token == "" || len(token) > maxSubmissionTokenLength {
+		return false
+	}
+
+	payload, ok := openWithKeyring(form.keyring, "idempotency", token)
+	return ok && len(payload) == 16
+}
+
+// idempotencyInput returns the hidden input holding a new signed submission
+// token, or an error message if the form has no keyring.
+func (form *Form) idempotencyInput() *hb.Tag {
+	if form.keyring == nil {
+		return hb.Div().Class("alert alert-danger").Text("Form Error. Idempotency needs a keyring, see Form.WithKeyring")
+	}
+
+	payload := make([]byte, 16)
+	_, _ = rand.Read(payload)
+
+	return hb.NewInput().
+		Type(hb.TYPE_HIDDEN).
+		Name(IdempotencyFieldName).
+		Value(signWithKeyring(form.keyring, "idempotency", payload))
+}
@@ -0,0 +1,253 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testIdempotencyKeyring, _ = NewKeyring("1", map[string][]byte{"1": []byte("idempotency key of at least 32 bytes")})
+
+func idempotencyTestForm(store IdempotencyStore) *Form {
+	return New().WithKeyring(testIdempotencyKeyring).WithIdempotency(store).WithFields(NewStringField("name", "Name"))
+}
+
+func renderedSubmissionToken(t *testing.T, f *Form) string {
+	t.Helper()
+
+	page := f.Build().ToHTML()
+	match := regexp.MustCompile(`name="submission_token"[^>]* value="([^"]+)"`).FindStringSubmatch(page)
+	if match == nil {
+		t.Fatal("Expected a submission token, got:", page)
+	}
+	return match[1]
+}
+
+func TestIdempotencyRendersNewTokens(t *testing.T) {
+	f := idempotencyTestForm(NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{}))
+
+	if renderedSubmissionToken(t, f) == renderedSubmissionToken(t, f) {
+		t.Fatal("Expected every build to render a new token")
+	}
+}
+
+func TestIdempotencyRejectsRepeatedSubmission(t *testing.T) {
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})
+	token := renderedSubmissionToken(t, idempotencyTestForm(store))
+	submitted := url.Values{"name": {"Jane"}, IdempotencyFieldName: {token}}
+
+	first := idempotencyTestForm(store)
+	values, err := first.ParseRequest(postForm(submitted))
+	if err != nil || values["name"] != "Jane" {
+		t.Fatal("Expected the first submission to pass, got:", values, err)
+	}
+	if _, found := values[IdempotencyFieldName]; found {
+		t.Fatal("Expected the token not to be returned as a value")
+	}
+
+	// The first submission is still being processed
+	second := idempotencyTestForm(store)
+	_, err = second.ParseRequest(postForm(submitted))
+	var submittedErr *AlreadySubmittedError
+	if !errors.Is(err, ErrAlreadySubmitted) || !errors.As(err, &submittedErr) || submittedErr.Response != nil {
+		t.Fatal("Expected an already submitted error without a response, got:", err)
+	}
+	if second.findField("name").GetValue() != "" {
+		t.Fatal("Expected the values not to be parsed")
+	}
+
+	header := http.Header{"Location": {"/orders/42"}}
+	if err := first.SaveResponse(context.Background(), CachedResponse{Status: http.StatusSeeOther, Header: header}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = idempotencyTestForm(store).ParseRequest(postForm(submitted))
+	if !errors.As(err, &submittedErr) || submittedErr.Response == nil {
+		t.Fatal("Expected the saved response, got:", err)
+	}
+
+	w := httptest.NewRecorder()
+	submittedErr.Response.Write(w)
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/orders/42" {
+		t.Fatal("Expected the saved response to be written, got:", w.Code, w.Header())
+	}
+
+	other := url.Values{"name": {"Jane"}, IdempotencyFieldName: {renderedSubmissionToken(t, first)}}
+	if _, err := idempotencyTestForm(store).ParseRequest(postForm(other)); err != nil {
+		t.Fatal("Expected a new token to pass, got:", err)
+	}
+}
+
+func TestIdempotencyRejectsInvalidTokens(t *testing.T) {
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})
+	token := renderedSubmissionToken(t, idempotencyTestForm(store))
+	otherKey := renderedSubmissionToken(t, New().WithKeyring(testKeyring(t, "1", "1")).WithIdempotency(store))
+
+	for _, invalid := range []string{"", "token", tamper(token), otherKey, token + strings.Repeat("A", maxSubmissionTokenLength)} {
+		f := idempotencyTestForm(store)
+		_, err := f.ParseRequest(postForm(url.Values{"name": {"Jane"}, IdempotencyFieldName: {invalid}}))
+		if !errors.Is(err, ErrInvalidSubmissionToken) {
+			t.Fatal("Expected an invalid token error for", invalid, "got:", err)
+		}
+	}
+
+	if claimed, _ := store.Claim(context.Background(), "token"); !claimed {
+		t.Fatal("Expected invalid tokens not to be stored")
+	}
+}
+
+func TestIdempotencyReleaseSubmission(t *testing.T) {
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})
+	token := renderedSubmissionToken(t, idempotencyTestForm(store))
+	submitted := url.Values{"name": {"Jane"}, IdempotencyFieldName: {token}}
+
+	first := idempotencyTestForm(store)
+	if _, err := first.ParseRequest(postForm(submitted)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Processing the first submission failed
+	if err := first.ReleaseSubmission(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.SaveResponse(context.Background(), CachedResponse{Body: []byte("ok")}); err != nil {
+		t.Fatal(err)
+	}
+
+	retry := idempotencyTestForm(store)
+	if _, err := retry.ParseRequest(postForm(submitted)); err != nil {
+		t.Fatal("Expected a released submission to pass again, got:", err)
+	}
+	if _, err := idempotencyTestForm(store).ParseRequest(postForm(submitted)); !errors.Is(err, ErrAlreadySubmitted) {
+		t.Fatal("Expected the retry to claim the token, got:", err)
+	}
+
+	if err := idempotencyTestForm(store).ReleaseSubmission(context.Background()); err != nil {
+		t.Fatal("Expected releasing without a submission to do nothing, got:", err)
+	}
+}
+
+func TestIdempotencyWithoutToken(t *testing.T) {
+	f := idempotencyTestForm(NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{}))
+
+	if _, err := f.ParseRequest(postForm(url.Values{"name": {"Jane"}})); !errors.Is(err, ErrInvalidSubmissionToken) {
+		t.Fatal("Expected a submission without a token to be rejected, got:", err)
+	}
+	if f.findField("name").GetValue() != "" {
+		t.Fatal("Expected the values not to be parsed")
+	}
+}
+
+func TestIdempotencyWithoutKeyring(t *testing.T) {
+	f := New().WithIdempotency(NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})).WithFields(NewStringField("name", "Name"))
+
+	page := f.Build().ToHTML()
+	if !strings.Contains(page, "Idempotency needs a keyring") || strings.Contains(page, IdempotencyFieldName) {
+		t.Fatal("Expected an error message instead of the token, got:", page)
+	}
+
+	_, err := f.ParseRequest(postForm(url.Values{"name": {"Jane"}, IdempotencyFieldName: {"token"}}))
+	if err == nil || !strings.Contains(err.Error(), "idempotency needs a keyring") {
+		t.Fatal("Expected a keyring error, got:", err)
+	}
+}
+
+func TestMemoryIdempotencyStoreExpires(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{
+		TTL: time.Hour,
+		Now: func() time.Time { return now },
+	})
+	ctx := context.Background()
+
+	if claimed, _ := store.Claim(ctx, "token"); !claimed {
+		t.Fatal("Expected a new token to be claimed")
+	}
+	_ = store.SaveResponse(ctx, "token", CachedResponse{Body: []byte("ok")})
+
+	now = now.Add(59 * time.Minute)
+	if claimed, _ := store.Claim(ctx, "token"); claimed {
+		t.Fatal("Expected a used token not to be claimed again")
+	}
+	if response, _ := store.Response(ctx, "token"); response == nil || string(response.Body) != "ok" {
+		t.Fatal("Expected the saved response, got:", response)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if response, _ := store.Response(ctx, "token"); response != nil {
+		t.Fatal("Expected the response to expire, got:", response)
+	}
+	if claimed, _ := store.Claim(ctx, "token"); !claimed {
+		t.Fatal("Expected an expired token to be claimed again")
+	}
+	if response, _ := store.Response(ctx, "token"); response != nil {
+		t.Fatal("Expected a claimed token to have no response, got:", response)
+	}
+
+	_ = store.SaveResponse(ctx, "token", CachedResponse{Body: []byte("ok")})
+	if err := store.Release(ctx, "token"); err != nil {
+		t.Fatal(err)
+	}
+	if response, _ := store.Response(ctx, "token"); response != nil {
+		t.Fatal("Expected a released token to have no response, got:", response)
+	}
+	if claimed, _ := store.Claim(ctx, "token"); !claimed {
+		t.Fatal("Expected a released token to be claimed again")
+	}
+}
+
+func TestMemoryIdempotencyStoreConcurrentClaims(t *testing.T) {
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	claims := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if claimed, _ := store.Claim(context.Background(), "token"); claimed {
+				mu.Lock()
+				claims++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if claims != 1 {
+		t.Fatal("Expected exactly one claim, got:", claims)
+	}
+}
+
+func TestRepeaterControllerKeepsSubmissionToken(t *testing.T) {
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreOptions{})
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm: func(r *http.Request) *Form {
+			return newControllerForm(r).WithKeyring(testIdempotencyKeyring).WithIdempotency(store)
+		},
+	})
+	token := renderedSubmissionToken(t, idempotencyTestForm(store))
+
+	values := threeItems()
+	values.Set(IdempotencyFieldName, token)
+	r := httptest.NewRequest(http.MethodPost, "/repeater?repeatable_add=1", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	controller.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatal("Expected the repeater action to run, got:", w.Code, w.Body.String())
+	}
+	if claimed, _ := store.Claim(context.Background(), token); !claimed {
+		t.Fatal("Expected a repeater action not to use the submission token")
+	}
+}
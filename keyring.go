@@ -0,0 +1,88 @@
+package form
+
+import (
+	"errors"
+	"strings"
+)
+
+// Keyring holds the keys that sign values, so they can be rotated: new values
+// are signed with the current key, while values signed with an older key
+// stay valid until it is removed from the keyring.
+type Keyring interface {
+	// Current returns the ID and secret of the key that signs new values.
+	Current() (id string, key []byte)
+
+	// Lookup returns the secret of the key with the ID, and false if the
+	// key is unknown or retired.
+	Lookup(id string) ([]byte, bool)
+}
+
+// staticKeyring is a Keyring of a fixed set of keys.
+type staticKeyring struct {
+	current string
+	keys    map[string][]byte
+}
+
+var _ Keyring = (*staticKeyring)(nil)
+
+// NewKeyring creates a Keyring of the keys, keyed by ID, that signs with the
+// current key. Key IDs must not contain dots. To rotate, add a new key and
+// make it current; remove the old key once the values it signed have expired.
+// Keys must be at least 32 bytes:
+//
+//	form.NewKeyring("2024-06", map[string][]byte{
+//	    "2024-06": newKey,
+//	    "2024-01": oldKey,
+//	})
+func NewKeyring(current string, keys map[string][]byte) (Keyring, error) {
+	if strings.Contains(current, ".") {
+		return nil, errors.New("form: key ID " + current + " must not contain dots")
+	}
+	if len(keys[current]) == 0 {
+		return nil, errors.New("form: current key " + current + " is not in the keyring")
+	}
+
+	copied := make(map[string][]byte, len(keys))
+	for id, key := range keys {
+		if len(key) < minKeyLength {
+			return nil, errors.New("form: key " + id + " must be at least 32 bytes")
+		}
+		copied[id] = key
+	}
+
+	return &staticKeyring{current: current, keys: copied}, nil
+}
+
+// Current returns the ID and secret of the current key.
+func (keyring *staticKeyring) Current() (string, []byte) {
+	return keyring.current, keyring.keys[keyring.current]
+}
+
+// Lookup returns the secret of the key with the ID.
+func (keyring *staticKeyring) Lookup(id string) ([]byte, bool) {
+	key, found := keyring.keys[id]
+	return key, found && len(key) > 0
+}
+
+// signWithKeyring returns the payload signed by signToken with the keyring's
+// current key, prefixed with the key ID and a dot.
+func signWithKeyring(keyring Keyring, purpose string, payload []byte) string {
+	id, key := keyring.Current()
+	return id + "." + signToken(key, purpose, payload)
+}
+
+// openWithKeyring returns the payload of a token created by signWithKeyring,
+// and reports whether it was signed with a key still in the keyring.
+func openWithKeyring(keyring Keyring, purpose string, token string) ([]byte, bool) {
+	id, signed, found := strings.Cut(token, ".")
+	if !found {
+		return nil, false
+	}
+
+	key, found := keyring.Lookup(id)
+	if !found {
+		return nil, false
+	}
+
+	return openToken(key, purpose, signed)
+}
@@ -0,0 +1,307 @@
+package form
+
+import (
+	"strings"
+)
+
+// Names of the built-in validation rules. The names are also the message keys
+// of the rules' errors, except for rules that report another rule's error
+// (e.g. "required_if" fails with the "required" message).
+const (
+	RuleRequired         = "required"
+	RuleMinLength        = "min_length"
+	RuleMaxLength        = "max_length"
+	RuleNumber           = "number"
+	RuleInteger          = "integer"
+	RuleUnsignedInteger  = "unsigned_integer"
+	RuleMin              = "min"
+	RuleMax              = "max"
+	RulePattern          = "pattern"
+	RuleEmail            = "email"
+	RuleURL              = "url"
+	RuleIP               = "ip"
+	RuleUUID             = "uuid"
+	RuleAlphaNumeric     = "alpha_numeric"
+	RuleOneOf            = "one_of"
+	RuleDate             = "date"
+	RuleDecimal          = "decimal"
+	RuleDateAfter        = "date_after"
+	RuleDateBefore       = "date_before"
+	RuleDateBetween      = "date_between"
+	RuleWeekday          = "weekday"
+	RuleIPv6             = "ipv6"
+	RuleCIDR             = "cidr"
+	RuleHostname         = "hostname"
+	RuleE164             = "e164"
+	RuleCreditCard       = "credit_card"
+	RuleCreditCardBrand  = "credit_card_brand"
+	RuleIBAN             = "iban"
+	RuleISBN             = "isbn"
+	RuleSemver           = "semver"
+	RuleSlug             = "slug"
+	RuleJSON             = "json"
+	RuleHexColor         = "hex_color"
+	RuleEmailAddress     = "email_address"
+	RuleMinGraphemes     = "min_graphemes"
+	RuleMaxGraphemes     = "max_graphemes"
+	RuleMaxBytes         = "max_bytes"
+	RuleConfusable       = "confusable"
+	RuleInvisible        = "invisible"
+	RuleSignature        = "signature"
+	RuleSameAs           = "same_as"
+	RuleGreaterThanField = "greater_than_field"
+	RuleRequiredIf       = "required_if"
+	RuleRequiredUnless   = "required_unless"
+	RuleCrossField       = "cross_field"
+	RuleCustom           = "custom"
+)
+
+// MessageErrorSummaryTitle is the message key of the error summary title.
+const MessageErrorSummaryTitle = "error_summary_title"
+
+// defaultLocale is the locale used when a message is missing in the form's locale.
+const defaultLocale = "en"
+
+// MessageProvider provides the message templates of validation rules for a locale.
+//
+// Templates reference the field as {field} and the rule parameters by name,
+// e.g. "{field} must be at least {min} characters".
+type MessageProvider interface {
+	// Message returns the template for the rule in the locale, and whether it was found.
+	Message(locale string, rule string) (string, bool)
+}
+
+// MessageCatalog is a MessageProvider backed by a map of locale to rule to template.
+type MessageCatalog map[string]map[string]string
+
+var _ MessageProvider = MessageCatalog{}
+
+// Message returns the template for the rule in the locale, and whether it was found.
+func (catalog MessageCatalog) Message(locale string, rule string) (string, bool) {
+	message, found := catalog[locale][rule]
+	return message, found
+}
+
+// DefaultMessages returns a copy of the built-in message catalog,
+// with English ("en"), German ("de") and Spanish ("es") messages.
+// It can be extended with further locales or overridden messages.
+func DefaultMessages() MessageCatalog {
+	catalog := MessageCatalog{}
+	for locale, messages := range defaultMessages {
+		catalog[locale] = map[string]string{}
+		for rule, message := range messages {
+			catalog[locale][rule] = message
+		}
+	}
+	return catalog
+}
+
+var defaultMessages = MessageCatalog{
+	"en": {
+		RuleRequired:         "{field} is required",
+		RuleMinLength:        "{field} must be at least {min} characters",
+		RuleMaxLength:        "{field} must be at most {max} characters",
+		RuleNumber:           "{field} must be a valid number",
+		RuleInteger:          "{field} must be a whole number",
+		RuleUnsignedInteger:  "{field} must be a positive whole number",
+		RuleMin:              "{field} must be at least {min}",
+		RuleMax:              "{field} must be at most {max}",
+		RulePattern:          "{field} has an invalid format",
+		RuleEmail:            "{field} must be a valid email address",
+		RuleURL:              "{field} must be a valid URL",
+		RuleIP:               "{field} must be a valid IP address",
+		RuleUUID:             "{field} must be a valid UUID",
+		RuleAlphaNumeric:     "{field} must contain only letters and numbers",
+		RuleOneOf:            "{field} must be one of the allowed values",
+		RuleDate:             "{field} must be a valid date",
+		RuleDecimal:          "{field} must have at most {scale} decimal places",
+		RuleDateAfter:        "{field} must be on or after {min}",
+		RuleDateBefore:       "{field} must be on or before {max}",
+		RuleDateBetween:      "{field} must be between {min} and {max}",
+		RuleWeekday:          "{field} must be on an allowed day of the week",
+		RuleIPv6:             "{field} must be a valid IPv6 address",
+		RuleCIDR:             "{field} must be a valid CIDR range",
+		RuleHostname:         "{field} must be a valid host name",
+		RuleE164:             "{field} must be a phone number in international format, e.g. +4930123456",
+		RuleCreditCard:       "{field} must be a valid card number",
+		RuleCreditCardBrand:  "{field} must be a card of an accepted type",
+		RuleIBAN:             "{field} must be a valid IBAN",
+		RuleISBN:             "{field} must be a valid ISBN",
+		RuleSemver:           "{field} must be a semantic version, e.g. 1.2.3",
+		RuleSlug:             "{field} must contain only lowercase letters, numbers and hyphens",
+		RuleJSON:             "{field} must be valid JSON",
+		RuleHexColor:         "{field} must be a hex color, e.g. #ff8800",
+		RuleMaxBytes:         "{field} is too long",
+		RuleConfusable:       "{field} contains characters that can be mistaken for others",
+		RuleInvisible:        "{field} must not contain invisible characters",
+		RuleSignature:        "{field} was modified and cannot be accepted",
+		RuleSameAs:           "{field} must match {other}",
+		RuleGreaterThanField: "{field} must be greater than {other}",
+
+		MessageErrorSummaryTitle: "There is a problem",
+	},
+	"de": {
+		RuleRequired:         "{field} ist erforderlich",
+		RuleMinLength:        "{field} muss mindestens {min} Zeichen lang sein",
+		RuleMaxLength:        "{field} darf höchstens {max} Zeichen lang sein",
+		RuleNumber:           "{field} muss eine gültige Zahl sein",
+		RuleInteger:          "{field} muss eine ganze Zahl sein",
+		RuleUnsignedInteger:  "{field} muss eine positive ganze Zahl sein",
+		RuleMin:              "{field} muss mindestens {min} sein",
+		RuleMax:              "{field} darf höchstens {max} sein",
+		RulePattern:          "{field} hat ein ungültiges Format",
+		RuleEmail:            "{field} muss eine gültige E-Mail-Adresse sein",
+		RuleURL:              "{field} muss eine gültige URL sein",
+		RuleIP:               "{field} muss eine gültige IP-Adresse sein",
+		RuleUUID:             "{field} muss eine gültige UUID sein",
+		RuleAlphaNumeric:     "{field} darf nur Buchstaben und Zahlen enthalten",
+		RuleOneOf:            "{field} muss einer der erlaubten Werte sein",
+		RuleDate:             "{field} muss ein gültiges Datum sein",
+		RuleDecimal:          "{field} darf höchstens {scale} Nachkommastellen haben",
+		RuleDateAfter:        "{field} muss am oder nach dem {min} liegen",
+		RuleDateBefore:       "{field} muss am oder vor dem {max} liegen",
+		RuleDateBetween:      "{field} muss zwischen {min} und {max} liegen",
+		RuleWeekday:          "{field} muss auf einen erlaubten Wochentag fallen",
+		RuleIPv6:             "{field} muss eine gültige IPv6-Adresse sein",
+		RuleCIDR:             "{field} muss ein gültiger CIDR-Bereich sein",
+		RuleHostname:         "{field} muss ein gültiger Hostname sein",
+		RuleE164:             "{field} muss eine Telefonnummer im internationalen Format sein, z. B. +4930123456",
+		RuleCreditCard:       "{field} muss eine gültige Kartennummer sein",
+		RuleCreditCardBrand:  "{field} muss eine Karte eines akzeptierten Typs sein",
+		RuleIBAN:             "{field} muss eine gültige IBAN sein",
+		RuleISBN:             "{field} muss eine gültige ISBN sein",
+		RuleSemver:           "{field} muss eine semantische Version sein, z. B. 1.2.3",
+		RuleSlug:             "{field} darf nur Kleinbuchstaben, Zahlen und Bindestriche enthalten",
+		RuleJSON:             "{field} muss gültiges JSON sein",
+		RuleHexColor:         "{field} muss eine Hex-Farbe sein, z. B. #ff8800",
+		RuleMaxBytes:         "{field} ist zu lang",
+		RuleConfusable:       "{field} enthält Zeichen, die mit anderen verwechselt werden können",
+		RuleInvisible:        "{field} darf keine unsichtbaren Zeichen enthalten",
+		RuleSignature:        "{field} wurde verändert und kann nicht akzeptiert werden",
+		RuleSameAs:           "{field} muss mit {other} übereinstimmen",
+		RuleGreaterThanField: "{field} muss größer als {other} sein",
+
+		MessageErrorSummaryTitle: "Es ist ein Problem aufgetreten",
+	},
+	"es": {
+		RuleRequired:         "{field} es obligatorio",
+		RuleMinLength:        "{field} debe tener al menos {min} caracteres",
+		RuleMaxLength:        "{field} debe tener como máximo {max} caracteres",
+		RuleNumber:           "{field} debe ser un número válido",
+		RuleInteger:          "{field} debe ser un número entero",
+		RuleUnsignedInteger:  "{field} debe ser un número entero positivo",
+		RuleMin:              "{field} debe ser como mínimo {min}",
+		RuleMax:              "{field} debe ser como máximo {max}",
+		RulePattern:          "{field} tiene un formato no válido",
+		RuleEmail:            "{field} debe ser una dirección de correo electrónico válida",
+		RuleURL:              "{field} debe ser una URL válida",
+		RuleIP:               "{field} debe ser una dirección IP válida",
+		RuleUUID:             "{field} debe ser un UUID válido",
+		RuleAlphaNumeric:     "{field} solo puede contener letras y números",
+		RuleOneOf:            "{field} debe ser uno de los valores permitidos",
+		RuleDate:             "{field} debe ser una fecha válida",
+		RuleDecimal:          "{field} debe tener como máximo {scale} decimales",
+		RuleDateAfter:        "{field} debe ser igual o posterior a {min}",
+		RuleDateBefore:       "{field} debe ser igual o anterior a {max}",
+		RuleDateBetween:      "{field} debe estar entre {min} y {max}",
+		RuleWeekday:          "{field} debe caer en un día de la semana permitido",
+		RuleIPv6:             "{field} debe ser una dirección IPv6 válida",
+		RuleCIDR:             "{field} debe ser un rango CIDR válido",
+		RuleHostname:         "{field} debe ser un nombre de host válido",
+		RuleE164:             "{field} debe ser un número de teléfono en formato internacional, p. ej. +4930123456",
+		RuleCreditCard:       "{field} debe ser un número de tarjeta válido",
+		RuleCreditCardBrand:  "{field} debe ser una tarjeta de un tipo aceptado",
+		RuleIBAN:             "{field} debe ser un IBAN válido",
+		RuleISBN:             "{field} debe ser un ISBN válido",
+		RuleSemver:           "{field} debe ser una versión semántica, p. ej. 1.2.3",
+		RuleSlug:             "{field} solo puede contener letras minúsculas, números y guiones",
+		RuleJSON:             "{field} debe ser un JSON válido",
+		RuleHexColor:         "{field} debe ser un color hexadecimal, p. ej. #ff8800",
+		RuleMaxBytes:         "{field} es demasiado largo",
+		RuleConfusable:       "{field} contiene caracteres que pueden confundirse con otros",
+		RuleInvisible:        "{field} no debe contener caracteres invisibles",
+		RuleSignature:        "{field} fue modificado y no puede aceptarse",
+		RuleSameAs:           "{field} debe coincidir con {other}",
+		RuleGreaterThanField: "{field} debe ser mayor que {other}",
+
+		MessageErrorSummaryTitle: "Se ha producido un problema",
+	},
+}
+
+// renderMessage replaces {field} and the {param} placeholders in the template.
+func renderMessage(template string, field string, params map[string]string) string {
+	replacements := []string{"{field}", field}
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+// localize re-renders the message of a rule error in the form's locale, using
+// the field's label (or name) and the labels of other fields referenced
+// by the "other" parameter. Errors without a rule keep their message.
+func (form *Form) localize(err *ValidationError, field FieldInterface) {
+	if err.Rule == "" {
+		return
+	}
+
+	template, found := form.message(err.Rule)
+	if !found {
+		return
+	}
+
+	params := err.Params
+	if other, ok := params["other"]; ok {
+		if otherField := form.findField(other); otherField != nil {
+			params = copyParams(params)
+			params["other"] = fieldLabel(otherField)
+		}
+	}
+
+	err.Message = renderMessage(template, fieldLabel(field), params)
+}
+
+// message returns the template for the rule, trying the form's locale, its base
+// language (e.g. "de" for "de-AT"), and finally the default locale, first in the
+// form's message provider and then in the built-in messages.
+func (form *Form) message(rule string) (string, bool) {
+	provider := form.messages
+	if provider == nil {
+		provider = defaultMessages
+	}
+
+	locales := []string{}
+	if form.locale != "" {
+		locales = append(locales, form.locale)
+		if base, _, found := strings.Cut(form.locale, "-"); found {
+			locales = append(locales, base)
+		}
+	}
+	locales = append(locales, defaultLocale)
+
+	for _, p := range []MessageProvider{provider, defaultMessages} {
+		for _, locale := range locales {
+			if template, found := p.Message(locale, rule); found {
+				return template, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// fieldLabel returns the field's label, falling back to its name.
+func fieldLabel(field FieldInterface) string {
+	if field.GetLabel() != "" {
+		return field.GetLabel()
+	}
+	return field.GetName()
+}
+
+func copyParams(params map[string]string) map[string]string {
+	copied := make(map[string]string, len(params))
+	for key, value := range params {
+		copied[key] = value
+	}
+	return copied
+}
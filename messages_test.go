@@ -0,0 +1,157 @@
+package form
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestValidateMessageUsesLabel(t *testing.T) {
+	f := New().WithFields(NewStringField("first_name", "First Name").WithRequired())
+
+	errs := f.Validate(map[string]string{"first_name": ""})
+	if len(errs) != 1 {
+		t.Fatal("Expected 1 error, got:", len(errs))
+	}
+	if errs[0].Message != "First Name is required" {
+		t.Fatal("Unexpected message:", errs[0].Message)
+	}
+	if errs[0].Field != "first_name" {
+		t.Fatal("Expected error for first_name, got:", errs[0].Field)
+	}
+	if errs[0].Rule != RuleRequired {
+		t.Fatal("Expected rule required, got:", errs[0].Rule)
+	}
+}
+
+func TestValidateMessageFallsBackToName(t *testing.T) {
+	f := New().WithFields(NewStringField("code", "").WithRequired())
+
+	errs := f.Validate(map[string]string{"code": ""})
+	if len(errs) != 1 || errs[0].Message != "code is required" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+}
+
+func TestValidateMessageLocales(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"de", "Name muss mindestens 3 Zeichen lang sein"},
+		{"es", "Name debe tener al menos 3 caracteres"},
+		{"de-AT", "Name muss mindestens 3 Zeichen lang sein"},
+		{"fr", "Name must be at least 3 characters"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			f := New().WithLocale(test.locale).WithFields(
+				NewStringField("name", "Name").WithValidators(ValidatorMinLength(3)),
+			)
+
+			errs := f.Validate(map[string]string{"name": "ab"})
+			if len(errs) != 1 || errs[0].Message != test.expected {
+				t.Fatal("Unexpected errors:", errs)
+			}
+		})
+	}
+}
+
+func TestValidateMessageCustomCatalog(t *testing.T) {
+	messages := DefaultMessages()
+	messages["en"][RuleRequired] = "Please enter {field}"
+	messages["fr"] = map[string]string{RuleMaxLength: "{field} doit contenir au plus {max} caractères"}
+
+	f := New().WithMessages(messages).WithFields(
+		NewStringField("name", "Name").WithRequired(),
+	)
+
+	errs := f.Validate(map[string]string{"name": ""})
+	if len(errs) != 1 || errs[0].Message != "Please enter Name" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+
+	f = New().WithMessages(messages).WithLocale("fr").WithFields(
+		NewStringField("name", "Nom").WithValidators(ValidatorMaxLength(2), ValidatorEmail()),
+	)
+
+	errs = f.Validate(map[string]string{"name": "abc"})
+	if len(errs) != 2 {
+		t.Fatal("Expected 2 errors, got:", errs)
+	}
+	if errs[0].Message != "Nom doit contenir au plus 2 caractères" {
+		t.Fatal("Unexpected message:", errs[0].Message)
+	}
+	// Missing in the catalog's "fr" and "en", falls back to the built-in message
+	if errs[1].Message != "Nom must be a valid email address" {
+		t.Fatal("Unexpected message:", errs[1].Message)
+	}
+
+	if DefaultMessages()["en"][RuleRequired] != "{field} is required" {
+		t.Fatal("DefaultMessages must return a copy")
+	}
+}
+
+func TestValidateMessageOtherFieldLabel(t *testing.T) {
+	f := New().WithLocale("de").WithFields(
+		NewPasswordField("password", "Passwort"),
+		NewPasswordField("password_confirmation", "Bestätigung").
+			WithValidators(ValidatorSameAs("password")),
+	)
+
+	errs := f.Validate(map[string]string{"password": "a", "password_confirmation": "b"})
+	if len(errs) != 1 || errs[0].Message != "Bestätigung muss mit Passwort übereinstimmen" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+}
+
+func TestValidateMessageCustomMessageUnchanged(t *testing.T) {
+	f := New().WithLocale("de").WithFields(
+		NewStringField("code", "Code").WithValidators(
+			ValidatorPattern(`^[A-Z]{3}$`, "must be 3 uppercase letters"),
+		),
+	)
+
+	errs := f.Validate(map[string]string{"code": "abc"})
+	if len(errs) != 1 || errs[0].Message != "must be 3 uppercase letters" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+}
+
+func TestValidateMessageRepeaterItem(t *testing.T) {
+	f := New().WithLocale("es").WithFields(
+		NewRepeater(RepeaterOptions{
+			Name: "addresses",
+			Fields: []FieldInterface{
+				NewStringField("city", "Ciudad").WithRequired(),
+			},
+		}),
+	)
+
+	errs := f.Validate(map[string]string{"addresses[city][0]": ""})
+	if len(errs) != 1 {
+		t.Fatal("Expected 1 error, got:", errs)
+	}
+	if errs[0].Field != "addresses[city][0]" || errs[0].Message != "Ciudad es obligatorio" {
+		t.Fatal("Unexpected error:", errs[0])
+	}
+}
+
+func TestBindMessageLocalized(t *testing.T) {
+	f := New().WithLocale("de").WithFields(NewNumberField("age", "Alter"))
+	f.ParseValues(url.Values{"age": {"abc"}})
+
+	var dst struct {
+		Age int `form:"age"`
+	}
+
+	err := f.Bind(&dst)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatal("Expected validation errors, got:", err)
+	}
+	if errs[0].Message != "Alter muss eine ganze Zahl sein" {
+		t.Fatal("Unexpected message:", errs[0].Message)
+	}
+}
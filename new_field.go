@@ -5,45 +5,53 @@ import "github.com/dracory/hb"
 // NewField creates a new Field with the given options.
 func NewField(opts FieldOptions) *Field {
 	return &Field{
-		ID:           opts.ID,
-		Type:         opts.Type,
-		Name:         opts.Name,
-		Label:        opts.Label,
-		Help:         opts.Help,
-		Options:      opts.Options,
-		OptionsF:     opts.OptionsF,
-		Value:        opts.Value,
-		Required:     opts.Required,
-		Readonly:     opts.Readonly,
-		Disabled:     opts.Disabled,
-		TableOptions: opts.TableOptions,
-		Placeholder:  opts.Placeholder,
-		Invisible:    opts.Invisible,
-		CustomInput:  opts.CustomInput,
-		Attrs:        opts.Attrs,
-		Multiple:     opts.Multiple,
-		Validators:   opts.Validators,
+		ID:                opts.ID,
+		Type:              opts.Type,
+		Name:              opts.Name,
+		Label:             opts.Label,
+		Help:              opts.Help,
+		Options:           opts.Options,
+		OptionsF:          opts.OptionsF,
+		Value:             opts.Value,
+		Required:          opts.Required,
+		Readonly:          opts.Readonly,
+		Disabled:          opts.Disabled,
+		TableOptions:      opts.TableOptions,
+		Placeholder:       opts.Placeholder,
+		Invisible:         opts.Invisible,
+		CustomInput:       opts.CustomInput,
+		Attrs:             opts.Attrs,
+		Multiple:          opts.Multiple,
+		Values:            opts.Values,
+		Validators:        opts.Validators,
+		ContextValidators: opts.ContextValidators,
+		Sanitizers:        opts.Sanitizers,
+		Signed:            opts.Signed,
 	}
 }
 
 // FieldOptions configures a new Field instance.
 type FieldOptions struct {
-	ID           string // automatic, if not assigned
-	Type         string
-	Name         string
-	Label        string
-	Help         string
-	Options      []FieldOption
-	OptionsF     func() []FieldOption
-	Value        string
-	Required     bool
-	Readonly     bool
-	Disabled     bool
-	TableOptions TableOptions
-	Placeholder  string
-	Invisible    bool
-	CustomInput  hb.TagInterface
-	Attrs        map[string]string
-	Multiple     bool
-	Validators   []Validator
+	ID                string // automatic, if not assigned
+	Type              string
+	Name              string
+	Label             string
+	Help              string
+	Options           []FieldOption
+	OptionsF          func() []FieldOption
+	Value             string
+	Required          bool
+	Readonly          bool
+	Disabled          bool
+	TableOptions      TableOptions
+	Placeholder       string
+	Invisible         bool
+	CustomInput       hb.TagInterface
+	Attrs             map[string]string
+	Multiple          bool
+	Values            []string
+	Validators        []Rule
+	ContextValidators []ContextValidator
+	Sanitizers        []Sanitizer
+	Signed            bool
 }
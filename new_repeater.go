@@ -10,6 +10,7 @@ func NewRepeater(opts RepeaterOptions) *fieldRepeater {
 		fieldValue:          opts.Value,
 		fields:              opts.Fields,
 		values:              opts.Values,
+		repeaterUrl:         opts.RepeaterUrl,
 		repeaterAddUrl:      opts.RepeaterAddUrl,
 		repeaterMoveUpUrl:   opts.RepeaterMoveUpUrl,
 		repeaterMoveDownUrl: opts.RepeaterMoveDownUrl,
@@ -26,6 +27,7 @@ type RepeaterOptions struct {
 	Help                string
 	Fields              []FieldInterface
 	Values              []map[string]string
+	RepeaterUrl         string // single URL for all actions, served by a RepeaterController
 	RepeaterAddUrl      string
 	RepeaterMoveUpUrl   string
 	RepeaterMoveDownUrl string
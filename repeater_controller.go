@@ -0,0 +1,126 @@
+package form
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/samber/lo"
+)
+
+// Names of the request parameters posted by the repeater buttons.
+const repeaterActionAdd = "repeatable_add"
+const repeaterActionRemove = "repeatable_remove_index"
+const repeaterActionMoveUp = "repeatable_move_up_index"
+const repeaterActionMoveDown = "repeatable_move_down_index"
+
+// RepeaterControllerOptions configures a new RepeaterController.
+type RepeaterControllerOptions struct {
+	// RepeaterName is the name of the repeater field the controller handles.
+	RepeaterName string
+
+	// BuildForm builds the form containing the repeater. It is called on every
+	// request; the submitted values are parsed into the returned form.
+	BuildForm func(r *http.Request) *Form
+}
+
+// RepeaterController is an http.Handler serving the add, remove, move up and
+// move down actions of a repeater from a single route.
+//
+// Point the repeater's RepeaterUrl at the controller's route. On each request
+// the controller builds the form, parses the submitted values into it, applies
+// the action to the repeater's items and responds with the re-rendered form,
+// which HTMX swaps in place of the form.
+type RepeaterController struct {
+	repeaterName string
+	buildForm    func(r *http.Request) *Form
+}
+
+var _ http.Handler = (*RepeaterController)(nil)
+
+// NewRepeaterController creates a new controller for the repeater actions.
+func NewRepeaterController(opts RepeaterControllerOptions) *RepeaterController {
+	return &RepeaterController{
+		repeaterName: opts.RepeaterName,
+		buildForm:    opts.BuildForm,
+	}
+}
+
+// ServeHTTP applies the requested repeater action and writes the re-rendered form.
+func (controller *RepeaterController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if controller.buildForm == nil {
+		http.Error(w, "Repeater controller has no BuildForm function", http.StatusInternalServerError)
+		return
+	}
+
+	form := controller.buildForm(r)
+	if form == nil {
+		http.Error(w, "Repeater controller BuildForm returned no form", http.StatusInternalServerError)
+		return
+	}
+
+	repeater, ok := form.findField(controller.repeaterName).(*fieldRepeater)
+	if !ok {
+		http.Error(w, "Repeater "+controller.repeaterName+" not found in form", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := form.parseRequest(r, false); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrInvalidCSRFToken) {
+			status = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	repeater.reorderItems(applyRepeaterAction(r, lo.Range(len(repeater.GetValues()))))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(form.Build().ToHTML()))
+}
+
+// applyRepeaterAction applies the add, remove, move up or move down action
+// found in the request parameters to the positions of the items. An added
+// item has the position -1.
+func applyRepeaterAction(r *http.Request, items []int) []int {
+	if index, ok := repeaterActionIndex(r, repeaterActionRemove, len(items)); ok {
+		return append(items[:index:index], items[index+1:]...)
+	}
+
+	if index, ok := repeaterActionIndex(r, repeaterActionMoveUp, len(items)); ok {
+		if index > 0 {
+			items[index-1], items[index] = items[index], items[index-1]
+		}
+		return items
+	}
+
+	if index, ok := repeaterActionIndex(r, repeaterActionMoveDown, len(items)); ok {
+		if index < len(items)-1 {
+			items[index], items[index+1] = items[index+1], items[index]
+		}
+		return items
+	}
+
+	if r.Form.Get(repeaterActionAdd) != "" {
+		return append(items, -1)
+	}
+
+	return items
+}
+
+// repeaterActionIndex returns the item index posted for the action,
+// if it is present and within the bounds of the items.
+func repeaterActionIndex(r *http.Request, action string, count int) (int, bool) {
+	value := r.Form.Get(action)
+	if value == "" {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 || index >= count {
+		return 0, false
+	}
+
+	return index, true
+}
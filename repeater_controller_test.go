@@ -0,0 +1,131 @@
+package form
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newControllerForm(r *http.Request) *Form {
+	return New().WithID("orderForm").WithFields(
+		NewStringField("customer", "Customer"),
+		NewRepeater(RepeaterOptions{
+			Name:        "items",
+			Fields:      []FieldInterface{NewStringField("sku", "SKU")},
+			RepeaterUrl: "/repeater",
+		}),
+	)
+}
+
+func serveRepeater(t *testing.T, query string, values url.Values) string {
+	t.Helper()
+
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm:    newControllerForm,
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/repeater?"+query, strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	controller.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatal("Expected status 200, got:", w.Code, w.Body.String())
+	}
+
+	return w.Body.String()
+}
+
+func threeItems() url.Values {
+	return url.Values{
+		"customer":      {"ACME"},
+		"items[sku][0]": {"A"},
+		"items[sku][1]": {"B"},
+		"items[sku][2]": {"C"},
+	}
+}
+
+func TestRepeaterControllerAdd(t *testing.T) {
+	html := serveRepeater(t, "repeatable_add=1", threeItems())
+
+	if !strings.Contains(html, `name="items[sku][3]"`) {
+		t.Fatal("Expected a fourth item, got:", html)
+	}
+	if !strings.Contains(html, `value="ACME"`) {
+		t.Fatal("Expected other fields to keep their values, got:", html)
+	}
+	if !strings.Contains(html, `id="orderForm"`) {
+		t.Fatal("Expected the whole form to be rendered, got:", html)
+	}
+}
+
+func TestRepeaterControllerRemove(t *testing.T) {
+	html := serveRepeater(t, "repeatable_remove_index=1", threeItems())
+
+	if strings.Contains(html, `value="B"`) {
+		t.Fatal("Expected item B to be removed, got:", html)
+	}
+	if !strings.Contains(html, `name="items[sku][1]" type="text" value="C"`) {
+		t.Fatal("Expected item C to move to index 1, got:", html)
+	}
+}
+
+func TestRepeaterControllerMoveUp(t *testing.T) {
+	html := serveRepeater(t, "repeatable_move_up_index=2", threeItems())
+
+	if !strings.Contains(html, `name="items[sku][1]" type="text" value="C"`) ||
+		!strings.Contains(html, `name="items[sku][2]" type="text" value="B"`) {
+		t.Fatal("Expected C and B to swap, got:", html)
+	}
+}
+
+func TestRepeaterControllerMoveDown(t *testing.T) {
+	html := serveRepeater(t, "repeatable_move_down_index=0", threeItems())
+
+	if !strings.Contains(html, `name="items[sku][0]" type="text" value="B"`) ||
+		!strings.Contains(html, `name="items[sku][1]" type="text" value="A"`) {
+		t.Fatal("Expected A and B to swap, got:", html)
+	}
+}
+
+func TestRepeaterControllerOutOfRange(t *testing.T) {
+	html := serveRepeater(t, "repeatable_remove_index=9", threeItems())
+
+	if !strings.Contains(html, `value="A"`) || !strings.Contains(html, `value="B"`) || !strings.Contains(html, `value="C"`) {
+		t.Fatal("Expected items to be unchanged, got:", html)
+	}
+}
+
+func TestRepeaterControllerRendersSingleUrl(t *testing.T) {
+	html := serveRepeater(t, "", url.Values{"items[sku][0]": {"A"}})
+
+	for _, expected := range []string{
+		`hx-post="/repeater?repeatable_add=1"`,
+		`hx-post="/repeater?&amp;repeatable_remove_index=0"`,
+		`hx-post="/repeater?&amp;repeatable_move_up_index=0"`,
+		`hx-post="/repeater?&amp;repeatable_move_down_index=0"`,
+		`hx-swap="outerHTML"`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Fatal("Expected:", expected, "got:", html)
+		}
+	}
+}
+
+func TestRepeaterControllerUnknownRepeater(t *testing.T) {
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "missing",
+		BuildForm:    newControllerForm,
+	})
+
+	w := httptest.NewRecorder()
+	controller.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/repeater", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatal("Expected status 500, got:", w.Code)
+	}
+}
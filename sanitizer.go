@@ -0,0 +1,163 @@
+package form
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Sanitizer cleans up a submitted value before it is validated,
+// e.g. by trimming spaces or lowercasing an email address.
+//
+// Sanitizers run in the order they are set on the field, when the form parses
+// a request, so the field keeps the cleaned value, and when it validates values.
+// Sanitizers should be idempotent, as a value may be sanitized more than once.
+type Sanitizer func(value string) string
+
+// SanitizerTrim returns a sanitizer that removes leading and trailing white space.
+func SanitizerTrim() Sanitizer {
+	return newNamedSanitizer("trim", strings.TrimSpace)
+}
+
+// SanitizerLower returns a sanitizer that converts a value to lower case.
+func SanitizerLower() Sanitizer {
+	return newNamedSanitizer("lower", strings.ToLower)
+}
+
+// SanitizerUpper returns a sanitizer that converts a value to upper case.
+func SanitizerUpper() Sanitizer {
+	return newNamedSanitizer("upper", strings.ToUpper)
+}
+
+// SanitizerCollapseSpaces returns a sanitizer that replaces every run of
+// white space, including line breaks, with a single space.
+func SanitizerCollapseSpaces() Sanitizer {
+	return newNamedSanitizer("collapse_spaces", collapseSpaces)
+}
+
+// SanitizerDigits returns a sanitizer that keeps only the digits 0-9,
+// e.g. for phone numbers.
+func SanitizerDigits() Sanitizer {
+	return newNamedSanitizer("digits", digitsOnly)
+}
+
+// SanitizerNFC returns a sanitizer that normalizes a value to Unicode NFC,
+// so that equal text is stored with the same bytes.
+func SanitizerNFC() Sanitizer {
+	return newNamedSanitizer("nfc", nfc)
+}
+
+// SanitizerStripHTML returns a sanitizer that removes HTML tags and comments,
+// and the content of script and style elements. Entities are kept as they are.
+func SanitizerStripHTML() Sanitizer {
+	return newNamedSanitizer("strip_html", stripHTML)
+}
+
+// SanitizerStripControl returns a sanitizer that removes control characters,
+// except tabs and line breaks.
+func SanitizerStripControl() Sanitizer {
+	return newNamedSanitizer("strip_control", stripControl)
+}
+
+var whiteSpacePattern = regexp.MustCompile(`\s+`)
+
+func collapseSpaces(value string) string {
+	return whiteSpacePattern.ReplaceAllString(value, " ")
+}
+
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func nfc(value string) string {
+	return norm.NFC.String(value)
+}
+
+var (
+	htmlScriptPattern  = regexp.MustCompile(`(?is)<script\b.*?</script\s*>`)
+	htmlStylePattern   = regexp.MustCompile(`(?is)<style\b.*?</style\s*>`)
+	htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagPattern     = regexp.MustCompile(`</?[a-zA-Z!][^>]*>`)
+)
+
+func stripHTML(value string) string {
+	value = htmlScriptPattern.ReplaceAllString(value, "")
+	value = htmlStylePattern.ReplaceAllString(value, "")
+	value = htmlCommentPattern.ReplaceAllString(value, "")
+	return htmlTagPattern.ReplaceAllString(value, "")
+}
+
+func stripControl(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// sanitize returns the value cleaned up by the field's sanitizers.
+func (field *Field) sanitize(value string) string {
+	for _, sanitizer := range field.Sanitizers {
+		value = sanitizer(value)
+	}
+	return value
+}
+
+// sanitizeValues returns a copy of the values, cleaned up by the sanitizers
+// of the fields, including the fields of every repeater item.
+func sanitizeValues(fields []FieldInterface, values map[string]string) map[string]string {
+	sanitized := make(map[string]string, len(values))
+	for key, value := range values {
+		sanitized[key] = value
+	}
+
+	for _, field := range flattenFields(fields) {
+		if repeater, ok := field.(*fieldRepeater); ok {
+			itemFields := flattenFields(repeater.fields)
+			for _, itemIndex := range repeaterItemIndexes(repeater.GetName(), values) {
+				for _, itemField := range itemFields {
+					key := repeaterItemFieldName(repeater.GetName(), itemField.GetName(), itemIndex)
+					sanitizeValue(itemField, key, sanitized)
+				}
+			}
+			continue
+		}
+
+		sanitizeValue(field, field.GetName(), sanitized)
+	}
+
+	return sanitized
+}
+
+// sanitizeValue cleans up the value under the key with the field's sanitizers.
+func sanitizeValue(field FieldInterface, key string, values map[string]string) {
+	f, ok := field.(*Field)
+	if !ok || len(f.Sanitizers) == 0 {
+		return
+	}
+
+	value, exists := values[key]
+	if !exists {
+		return
+	}
+
+	// Each value selected in a multiple select is sanitized on its own
+	if f.Multiple {
+		selected := splitSelected(value)
+		for i := range selected {
+			selected[i] = f.sanitize(selected[i])
+		}
+		values[key] = joinSelected(selected)
+		return
+	}
+
+	values[key] = f.sanitize(value)
+}
@@ -0,0 +1,139 @@
+package form
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSanitizers(t *testing.T) {
+	tests := []struct {
+		name      string
+		sanitizer Sanitizer
+		value     string
+		expected  string
+	}{
+		{"trim", SanitizerTrim(), "  Jane \n", "Jane"},
+		{"lower", SanitizerLower(), "Jane@Example.COM", "jane@example.com"},
+		{"upper", SanitizerUpper(), "de89 3704", "DE89 3704"},
+		{"collapse spaces", SanitizerCollapseSpaces(), "Jane \t  Mary\n\nDoe", "Jane Mary Doe"},
+		{"digits", SanitizerDigits(), "+44 (0)20-7946 0958", "4402079460958"},
+		{"nfc", SanitizerNFC(), "Zoe\u0308", "Zo\u00eb"},
+		{"strip html", SanitizerStripHTML(), `<p>Hello <b>world</b><!-- note --></p><script>alert("x")</script> 1 < 2 &amp; 3`, "Hello world 1 < 2 &amp; 3"},
+		{"strip control", SanitizerStripControl(), "Jane\x00\x1b\u200b Doe\tand\r\nmore", "Jane\u200b Doe\tand\r\nmore"},
+	}
+
+	for _, test := range tests {
+		if got := test.sanitizer(test.value); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, got)
+		}
+		if got := test.sanitizer(test.sanitizer(test.value)); got != test.expected {
+			t.Errorf("%s: expected the sanitizer to be idempotent, got %q", test.name, got)
+		}
+	}
+}
+
+func TestParseValuesSanitizes(t *testing.T) {
+	email := NewEmailField("email", "Email").WithSanitizers(SanitizerTrim(), SanitizerLower())
+	sizes := NewSelectField("sizes", "Sizes", nil).WithMultiple().WithSanitizers(SanitizerUpper())
+	name := NewStringField("name", "Name")
+	f := New().WithFields(
+		NewFieldRow(email, name),
+		sizes,
+		NewRepeater(RepeaterOptions{
+			Name:   "phones",
+			Fields: []FieldInterface{NewTelField("number", "Number").WithSanitizers(SanitizerDigits())},
+		}),
+	)
+
+	values := f.ParseValues(url.Values{
+		"email":             {"  Jane@Example.COM "},
+		"name":              {"  Jane "},
+		"sizes[]":           {"s", "m"},
+		"phones[number][0]": {"+49 30 1234"},
+	})
+
+	if values["email"] != "jane@example.com" || email.Value != "jane@example.com" {
+		t.Fatal("Expected the sanitized email, got:", values["email"], email.Value)
+	}
+
+	if values["name"] != "  Jane " {
+		t.Fatal("Expected the name to be left as submitted, got:", values["name"])
+	}
+
+	if values["sizes"] != `["S","M"]` || strings.Join(sizes.Values, "|") != "S|M" {
+		t.Fatal("Expected the sanitized sizes, got:", values["sizes"], sizes.Values)
+	}
+
+	if values["phones[number][0]"] != "49301234" {
+		t.Fatal("Expected the sanitized repeater value, got:", values["phones[number][0]"])
+	}
+
+	if html := f.Build().ToHTML(); !strings.Contains(html, `value="jane@example.com"`) {
+		t.Fatal("Expected the form to render the sanitized value, got:", html)
+	}
+}
+
+func TestValidateSanitizes(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("username", "Username").
+			WithRequired().
+			WithSanitizers(SanitizerTrim(), SanitizerLower()).
+			WithValidators(ValidatorMaxLength(4), ValidatorOneOf("jane")),
+		NewStringField("confirm", "Confirm").WithValidators(ValidatorSameAs("username")),
+		NewRepeater(RepeaterOptions{
+			Name:        "phones",
+			RepeaterUrl: "/repeater",
+			Fields: []FieldInterface{
+				NewTelField("number", "Number").WithSanitizers(SanitizerDigits()).WithValidators(ValidatorMaxLength(4)),
+			},
+		}),
+	)
+
+	values := map[string]string{
+		"username":          "  JANE  ",
+		"confirm":           "jane",
+		"phones[number][0]": "12-34",
+	}
+
+	if errs := f.Validate(values); len(errs) != 0 {
+		t.Fatal("Expected the sanitized values to pass, got:", errs)
+	}
+
+	if values["username"] != "  JANE  " {
+		t.Fatal("Expected Validate to leave the given values unchanged")
+	}
+
+	if errs := f.Validate(map[string]string{"username": "   "}); len(errs) != 1 || errs[0].Rule != RuleRequired {
+		t.Fatal("Expected a required error, got:", errs)
+	}
+}
+
+func TestSanitizersDefinition(t *testing.T) {
+	trimmed := NewStringField("name", "Name").WithSanitizers(SanitizerTrim(), SanitizerNFC(), SanitizerStripHTML())
+	f := New().WithFields(trimmed)
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"sanitizers": [`) || !strings.Contains(string(data), `"strip_html"`) {
+		t.Fatal("Expected the sanitizers by name, got:", string(data))
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := loaded.ParseValues(url.Values{"name": {" <b>Zoe\u0308</b> "}})
+	if values["name"] != "Zo\u00eb" {
+		t.Fatal("Expected the restored sanitizers to run, got:", values["name"])
+	}
+
+	unregistered := New().WithFields(NewStringField("name", "Name").WithSanitizers(func(value string) string { return value }))
+	if _, err := unregistered.MarshalDefinition(); err == nil || !strings.Contains(err.Error(), "sanitizer is not registered") {
+		t.Fatal("Expected an unregistered sanitizer error, got:", err)
+	}
+}
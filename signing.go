@@ -0,0 +1,59 @@
+package form
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// minKeyLength is the least number of bytes of a key that signs tokens.
+const minKeyLength = 32
+
+// checkKey returns an error if the key is too short to sign tokens safely.
+func checkKey(key []byte) error {
+	if len(key) < minKeyLength {
+		return errors.New("form: key must be at least 32 bytes")
+	}
+	return nil
+}
+
+// signToken returns the payload followed by its signature for the purpose,
+// both base64url encoded and separated by a dot.
+func signToken(key []byte, purpose string, payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(hmacSum(key, purpose, payload))
+}
+
+// openToken returns the payload of a token created by signToken with the
+// same key and purpose, and reports whether its signature is valid.
+func openToken(key []byte, purpose string, token string) ([]byte, bool) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, false
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, hmacSum(key, purpose, payload)) {
+		return nil, false
+	}
+
+	return payload, true
+}
+
+// hmacSum returns the HMAC-SHA256 of the payload. The purpose separates the
+// signatures of different uses of the same key, e.g. "csrf" from "spam", so a
+// token made for one use is rejected by another.
+func hmacSum(key []byte, purpose string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
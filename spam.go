@@ -0,0 +1,153 @@
+package form
+
+import (
+	"encoding/binary"
+	"net/http"
+	"time"
+
+	"github.com/dracory/hb"
+)
+
+// SpamTimestampFieldName is the name of the hidden input holding the signed
+// time at which a form with spam protection was rendered.
+const SpamTimestampFieldName = "form_rendered_at"
+
+// Reasons of a SpamDetected result.
+const (
+	SpamReasonHoneypot  = "honeypot"  // the honeypot field was filled in
+	SpamReasonTooFast   = "too_fast"  // the form was submitted faster than MinSubmitTime
+	SpamReasonTimestamp = "timestamp" // the render timestamp is missing, tampered with or older than MaxAge
+)
+
+// SpamDetected is returned by ParseRequest when a form with spam protection
+// receives a submission that looks automated. Handlers usually respond as
+// if the submission succeeded, so bots learn nothing:
+//
+//	var spam *form.SpamDetected
+//	if errors.As(err, &spam) {
+//	    log.Println("spam:", spam.Reason)
+//	}
+type SpamDetected struct {
+	Reason string // SpamReasonHoneypot, SpamReasonTooFast or SpamReasonTimestamp
+}
+
+// Error returns the reason of the spam detection.
+func (spam *SpamDetected) Error() string {
+	return "form: spam detected: " + spam.Reason
+}
+
+// SpamProtectionOptions configures the spam protection of a form.
+type SpamProtectionOptions struct {
+	// Key signs the render timestamp. It must be secret, at least 32 random bytes.
+	Key []byte
+
+	// HoneypotName is the name of the honeypot field, "website" by default.
+	// Pick a name bots are tempted to fill in that no real field uses.
+	HoneypotName string
+
+	// HoneypotLabel is the label of the honeypot field, for the few people
+	// who see it, "Leave this field empty" by default.
+	HoneypotLabel string
+
+	// MinSubmitTime is the least time between rendering and submitting the
+	// form that a person needs, 3 seconds by default.
+	MinSubmitTime time.Duration
+
+	// MaxAge is how long a rendered form can be submitted, 24 hours by default.
+	MaxAge time.Duration
+
+	// Now returns the current time, time.Now by default.
+	Now func() time.Time
+}
+
+// WithSpamProtection adds a honeypot field, hidden from people with the
+// theme's HoneypotClass, and a signed render timestamp to the form.
+// ParseRequest returns a *SpamDetected error, without parsing the values,
+// if the honeypot is filled in or the form is submitted too fast.
+//
+// If the key is shorter than 32 bytes, Build renders an error message instead
+// of the timestamp and ParseRequest returns an error.
+func (form *Form) WithSpamProtection(opts SpamProtectionOptions) *Form {
+	if opts.HoneypotName == "" {
+		opts.HoneypotName = "website"
+	}
+	if opts.HoneypotLabel == "" {
+		opts.HoneypotLabel = "Leave this field empty"
+	}
+	if opts.MinSubmitTime <= 0 {
+		opts.MinSubmitTime = 3 * time.Second
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = 24 * time.Hour
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	form.spamProtection = &opts
+	return form
+}
+
+// verifySpam checks the honeypot and render timestamp of a parsed request.
+func (form *Form) verifySpam(r *http.Request) error {
+	opts := form.spamProtection
+	if opts == nil {
+		return nil
+	}
+
+	if err := checkKey(opts.Key); err != nil {
+		return err
+	}
+
+	if r.Form.Get(opts.HoneypotName) != "" {
+		return &SpamDetected{Reason: SpamReasonHoneypot}
+	}
+
+	payload, ok := openToken(opts.Key, "spam", r.Form.Get(SpamTimestampFieldName))
+	if !ok || len(payload) != 8 {
+		return &SpamDetected{Reason: SpamReasonTimestamp}
+	}
+
+	rendered := time.UnixMilli(int64(binary.BigEndian.Uint64(payload)))
+	age := opts.Now().Sub(rendered)
+	if age > opts.MaxAge {
+		return &SpamDetected{Reason: SpamReasonTimestamp}
+	}
+	if age < opts.MinSubmitTime {
+		return &SpamDetected{Reason: SpamReasonTooFast}
+	}
+
+	return nil
+}
+
+// spamInputs returns the honeypot field and the signed render timestamp,
+// or an error message if the key is too short.
+func (form *Form) spamInputs(theme *Theme) []hb.TagInterface {
+	opts := form.spamProtection
+	if checkKey(opts.Key) != nil {
+		return []hb.TagInterface{hb.Div().Class("alert alert-danger").Text("Form Error. Spam protection key must be at least 32 bytes")}
+	}
+
+	payload := binary.BigEndian.AppendUint64(nil, uint64(opts.Now().UnixMilli()))
+	timestamp := hb.NewInput().
+		Type(hb.TYPE_HIDDEN).
+		Name(SpamTimestampFieldName).
+		Value(signToken(opts.Key, "spam", payload))
+
+	honeypotID := "id_" + opts.HoneypotName + "_hp"
+	honeypot := hb.NewDiv().
+		Class(theme.HoneypotClass).
+		Attr("aria-hidden", "true").
+		Child(hb.NewLabel().
+			Attr("for", honeypotID).
+			Text(opts.HoneypotLabel)).
+		Child(hb.NewInput().
+			Type(hb.TYPE_TEXT).
+			ID(honeypotID).
+			Name(opts.HoneypotName).
+			Value("").
+			Attr("tabindex", "-1").
+			Attr("autocomplete", "off"))
+
+	return []hb.TagInterface{honeypot, timestamp}
+}
@@ -0,0 +1,138 @@
+package form
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// spamTestForm returns a form with spam protection whose clock is read from now.
+func spamTestForm(now *time.Time) *Form {
+	return New().WithID("contact").WithFields(
+		NewStringField("name", "Name"),
+	).WithSpamProtection(SpamProtectionOptions{
+		Key: testCSRFKey,
+		Now: func() time.Time { return *now },
+	})
+}
+
+func renderedTimestamp(t *testing.T, page string) string {
+	t.Helper()
+
+	match := regexp.MustCompile(`name="form_rendered_at"[^>]* value="([^"]+)"`).FindStringSubmatch(page)
+	if match == nil {
+		t.Fatal("Expected a render timestamp, got:", page)
+	}
+	return match[1]
+}
+
+func postForm(values url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestSpamProtectionShortKey(t *testing.T) {
+	f := New().WithSpamProtection(SpamProtectionOptions{Key: []byte("short")}).WithFields(NewStringField("name", "Name"))
+
+	page := f.Build().ToHTML()
+	if !strings.Contains(page, "Spam protection key must be at least 32 bytes") || strings.Contains(page, SpamTimestampFieldName) {
+		t.Fatal("Expected an error message instead of the timestamp, got:", page)
+	}
+
+	_, err := f.ParseRequest(postForm(url.Values{"name": {"Jane"}}))
+	if err == nil || err.Error() != "form: key must be at least 32 bytes" {
+		t.Fatal("Expected a short key error, got:", err)
+	}
+}
+
+func TestSpamProtectionRendersHoneypot(t *testing.T) {
+	now := time.Now()
+
+	page := spamTestForm(&now).Build().ToHTML()
+	if !strings.Contains(page, `<div aria-hidden="true" class="visually-hidden"><label for="id_website_hp">Leave this field empty</label>`) {
+		t.Fatal("Expected a hidden honeypot, got:", page)
+	}
+	if !strings.Contains(page, `name="website" tabindex="-1"`) {
+		t.Fatal("Expected the honeypot out of the tab order, got:", page)
+	}
+
+	page = spamTestForm(&now).WithTheme(ThemeTailwind()).Build().ToHTML()
+	if !strings.Contains(page, `class="sr-only"`) {
+		t.Fatal("Expected the theme's honeypot class, got:", page)
+	}
+}
+
+func TestSpamProtectionParseRequest(t *testing.T) {
+	rendered := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	now := rendered
+	timestamp := renderedTimestamp(t, spamTestForm(&now).Build().ToHTML())
+
+	tests := []struct {
+		name   string
+		after  time.Duration
+		values url.Values
+		reason string
+	}{
+		{"person", 10 * time.Second, url.Values{"name": {"Jane"}, SpamTimestampFieldName: {timestamp}}, ""},
+		{"empty honeypot", 10 * time.Second, url.Values{"name": {"Jane"}, "website": {""}, SpamTimestampFieldName: {timestamp}}, ""},
+		{"filled honeypot", 10 * time.Second, url.Values{"name": {"Jane"}, "website": {"http://spam"}, SpamTimestampFieldName: {timestamp}}, SpamReasonHoneypot},
+		{"too fast", time.Second, url.Values{"name": {"Jane"}, SpamTimestampFieldName: {timestamp}}, SpamReasonTooFast},
+		{"no timestamp", 10 * time.Second, url.Values{"name": {"Jane"}}, SpamReasonTimestamp},
+		{"tampered timestamp", 10 * time.Second, url.Values{"name": {"Jane"}, SpamTimestampFieldName: {tamper(timestamp)}}, SpamReasonTimestamp},
+		{"expired timestamp", 25 * time.Hour, url.Values{"name": {"Jane"}, SpamTimestampFieldName: {timestamp}}, SpamReasonTimestamp},
+	}
+
+	for _, test := range tests {
+		now = rendered.Add(test.after)
+		f := spamTestForm(&now)
+		values, err := f.ParseRequest(postForm(test.values))
+
+		var spam *SpamDetected
+		if test.reason == "" {
+			if err != nil || values["name"] != "Jane" {
+				t.Errorf("%s: expected to pass, got %v %v", test.name, values, err)
+			}
+			continue
+		}
+
+		if !errors.As(err, &spam) || spam.Reason != test.reason {
+			t.Errorf("%s: expected spam %q, got %v", test.name, test.reason, err)
+		}
+		if f.findField("name").GetValue() != "" {
+			t.Errorf("%s: expected the values not to be parsed", test.name)
+		}
+	}
+}
+
+func TestSpamProtectionTimestampIsNotACSRFToken(t *testing.T) {
+	now := time.Now()
+	timestamp := renderedTimestamp(t, spamTestForm(&now).Build().ToHTML())
+
+	if testCSRFProvider("", now).Verify(timestamp) {
+		t.Fatal("Expected a token signed for spam protection to be rejected as a CSRF token")
+	}
+}
+
+func TestRepeaterControllerSkipsSpamTiming(t *testing.T) {
+	controller := NewRepeaterController(RepeaterControllerOptions{
+		RepeaterName: "items",
+		BuildForm: func(r *http.Request) *Form {
+			return newControllerForm(r).WithSpamProtection(SpamProtectionOptions{Key: testCSRFKey})
+		},
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/repeater?repeatable_add=1", strings.NewReader(threeItems().Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	controller.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `name="items[sku][3]"`) {
+		t.Fatal("Expected the repeater action to run, got:", w.Code, w.Body.String())
+	}
+}
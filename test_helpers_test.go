@@ -103,10 +103,10 @@ func TestHelpersEndToEnd(t *testing.T) {
 		"email": "john@example.com",
 	})
 
-	// Test validation fails (only top-level fields are validated; row children are nested)
+	// Test validation fails (row children are validated too)
 	AssertValidationErrorCount(t, f, map[string]string{
 		"email": "invalid",
-	}, 1)
+	}, 3)
 
 	// Test inline errors render
 	f.Validate(map[string]string{
@@ -20,6 +20,13 @@ type Theme struct {
 	TableClass         string
 	ErrorClass         string // CSS class for the error message element
 	ErrorInputClass    string // CSS class added to invalid inputs
+	ErrorListClass     string // CSS class for the list of a field's error messages, when it has several
+
+	ErrorSummaryClass      string // CSS class for the error summary block at the top of the form
+	ErrorSummaryTitleClass string // CSS class for the error summary title
+	ErrorSummaryListClass  string // CSS class for the list of errors in the error summary
+
+	HoneypotClass string // CSS class hiding the spam protection honeypot field from people
 }
 
 // ThemeBootstrap5 returns the default Bootstrap 5 theme.
@@ -42,6 +49,13 @@ func ThemeBootstrap5() *Theme {
 		TableClass:         "table table-striped table-hover mb-0",
 		ErrorClass:         "invalid-feedback",
 		ErrorInputClass:    "is-invalid",
+		ErrorListClass:     "list-unstyled mb-0",
+
+		ErrorSummaryClass:      "alert alert-danger",
+		ErrorSummaryTitleClass: "alert-heading h5",
+		ErrorSummaryListClass:  "mb-0",
+
+		HoneypotClass: "visually-hidden",
 	}
 }
 
@@ -65,6 +79,13 @@ func ThemeTailwind() *Theme {
 		TableClass:         "min-w-full divide-y divide-gray-200",
 		ErrorClass:         "mt-1 text-sm text-red-600",
 		ErrorInputClass:    "border-red-500",
+		ErrorListClass:     "list-none",
+
+		ErrorSummaryClass:      "mb-4 rounded-md border border-red-500 bg-red-50 p-4",
+		ErrorSummaryTitleClass: "text-sm font-medium text-red-800",
+		ErrorSummaryListClass:  "mt-2 list-disc pl-5 text-sm text-red-700",
+
+		HoneypotClass: "sr-only",
 	}
 }
 
@@ -2,144 +2,184 @@ package form
 
 import (
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // ValidationError represents a single validation error for a field.
 type ValidationError struct {
 	Field   string
 	Message string
+	Rule    string            // message key of the failed rule, e.g. "min_length"; empty for custom messages
+	Params  map[string]string // rule parameters available to message templates, e.g. "min"
+}
+
+// ValidationErrors is a list of validation errors that can be returned as an error.
+type ValidationErrors []ValidationError
+
+// Error joins the error messages of all validation errors.
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Field + ": " + e.Message
+	}
+	return strings.Join(messages, "; ")
 }
 
 // Validator is a function that validates a field value and returns an error message if invalid.
+// Validators that need the values of other fields are FormValidators, see ValidatorCrossField.
+//
+// Validator implements Rule, named "custom", so a func can be used wherever
+// a Rule is expected. Prefer the Validator* constructors, whose rules
+// can be inspected.
 type Validator func(fieldName string, value string) *ValidationError
 
+// ruleError creates a validation error for a rule, with the English message
+// rendered from the rule's default template and the field name.
+func ruleError(fieldName string, rule string, params map[string]string) *ValidationError {
+	return &ValidationError{
+		Field:   fieldName,
+		Message: renderMessage(defaultMessages[defaultLocale][rule], fieldName, params),
+		Rule:    rule,
+		Params:  params,
+	}
+}
+
 // ValidatorRequired returns a validator that checks if a value is non-empty.
-func ValidatorRequired() Validator {
-	return func(fieldName string, value string) *ValidationError {
+func ValidatorRequired() Rule {
+	return newRule(RuleRequired, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		if strings.TrimSpace(value) == "" {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " is required",
-			}
+			return ruleError(fieldName, RuleRequired, nil)
 		}
 		return nil
-	}
+	})
 }
 
-// ValidatorMinLength returns a validator that checks if a value has at least minLength characters.
-func ValidatorMinLength(minLength int) Validator {
-	return func(fieldName string, value string) *ValidationError {
-		if len(value) < minLength {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be at least " + strconv.Itoa(minLength) + " characters",
-			}
+// ValidatorMinLength returns a validator that checks if a value has at least
+// minLength characters, counted as Unicode code points.
+func ValidatorMinLength(minLength int) Rule {
+	params := map[string]string{"min": strconv.Itoa(minLength)}
+	return newRule(RuleMinLength, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if utf8.RuneCountInString(value) < minLength {
+			return ruleError(fieldName, RuleMinLength, params)
 		}
 		return nil
-	}
+	})
 }
 
-// ValidatorMaxLength returns a validator that checks if a value has at most maxLength characters.
-func ValidatorMaxLength(maxLength int) Validator {
-	return func(fieldName string, value string) *ValidationError {
-		if len(value) > maxLength {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be at most " + strconv.Itoa(maxLength) + " characters",
-			}
+// ValidatorMaxLength returns a validator that checks if a value has at most
+// maxLength characters, counted as Unicode code points.
+func ValidatorMaxLength(maxLength int) Rule {
+	params := map[string]string{"max": strconv.Itoa(maxLength)}
+	return newRule(RuleMaxLength, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if utf8.RuneCountInString(value) > maxLength {
+			return ruleError(fieldName, RuleMaxLength, params)
 		}
 		return nil
-	}
+	})
 }
 
 // ValidatorMin returns a validator that checks if a numeric value is at least min.
-func ValidatorMin(min float64) Validator {
-	return func(fieldName string, value string) *ValidationError {
+func ValidatorMin(min float64) Rule {
+	params := map[string]string{"min": strconv.FormatFloat(min, 'f', -1, 64)}
+	return newRule(RuleMin, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be a valid number",
-			}
+			return ruleError(fieldName, RuleNumber, nil)
 		}
 		if v < min {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be at least " + strconv.FormatFloat(min, 'f', -1, 64),
-			}
+			return ruleError(fieldName, RuleMin, params)
 		}
 		return nil
-	}
+	})
 }
 
 // ValidatorMax returns a validator that checks if a numeric value is at most max.
-func ValidatorMax(max float64) Validator {
-	return func(fieldName string, value string) *ValidationError {
+func ValidatorMax(max float64) Rule {
+	params := map[string]string{"max": strconv.FormatFloat(max, 'f', -1, 64)}
+	return newRule(RuleMax, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be a valid number",
-			}
+			return ruleError(fieldName, RuleNumber, nil)
 		}
 		if v > max {
-			return &ValidationError{
-				Field:   fieldName,
-				Message: fieldName + " must be at most " + strconv.FormatFloat(max, 'f', -1, 64),
-			}
+			return ruleError(fieldName, RuleMax, params)
 		}
 		return nil
-	}
+	})
+}
+
+// ValidatorInteger returns a validator that checks if a value is a whole
+// number, e.g. "42" or "-7". Number inputs with it get step="1".
+func ValidatorInteger() Rule {
+	return patternRule(RuleInteger, nil, integerPattern, "")
 }
 
+// integerPattern is the pattern checked by ValidatorInteger.
+const integerPattern = `^-?[0-9]+$`
+
 // ValidatorPattern returns a validator that checks if a value matches a regex pattern.
-func ValidatorPattern(pattern string, message string) Validator {
+// The message is used as-is; when empty, the translatable "pattern" message is used.
+func ValidatorPattern(pattern string, message string) Rule {
+	params := map[string]string{"pattern": pattern}
+	if message != "" {
+		params["message"] = message
+	}
+	return patternRule(RulePattern, params, pattern, message)
+}
+
+// patternRule returns a rule that checks if a value matches a regex pattern,
+// failing with the given message or, when empty, the message of the rule.
+func patternRule(name string, params map[string]string, pattern string, message string) Rule {
 	re := regexp.MustCompile(pattern)
-	return func(fieldName string, value string) *ValidationError {
+	return newRule(name, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		if value != "" && !re.MatchString(value) {
-			msg := message
-			if msg == "" {
-				msg = fieldName + " has an invalid format"
-			}
-			return &ValidationError{
-				Field:   fieldName,
-				Message: msg,
+			if message != "" {
+				return &ValidationError{
+					Field:   fieldName,
+					Message: message,
+				}
 			}
+			return ruleError(fieldName, name, nil)
 		}
 		return nil
-	}
+	})
 }
 
 // ValidatorEmail returns a validator that checks if a value is a valid email address.
-func ValidatorEmail() Validator {
-	return ValidatorPattern(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`, "must be a valid email address")
+func ValidatorEmail() Rule {
+	return patternRule(RuleEmail, nil, `^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`, "")
 }
 
 // ValidatorURL returns a validator that checks if a value is a valid URL.
-func ValidatorURL() Validator {
-	return ValidatorPattern(`^https?://[^\s/$.?#].[^\s]*$`, "must be a valid URL")
+func ValidatorURL() Rule {
+	return patternRule(RuleURL, nil, `^https?://[^\s/$.?#].[^\s]*$`, "")
 }
 
 // ValidatorIP returns a validator that checks if a value is a valid IPv4 address.
-func ValidatorIP() Validator {
-	return ValidatorPattern(`^((25[0-5]|(2[0-4]|1\d|[1-9]|)\d)\.?\b){4}$`, "must be a valid IP address")
+func ValidatorIP() Rule {
+	return patternRule(RuleIP, nil, `^((25[0-5]|(2[0-4]|1\d|[1-9]|)\d)\.?\b){4}$`, "")
 }
 
 // ValidatorUUID returns a validator that checks if a value is a valid UUID.
-func ValidatorUUID() Validator {
-	return ValidatorPattern(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`, "must be a valid UUID")
+func ValidatorUUID() Rule {
+	return patternRule(RuleUUID, nil, `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`, "")
 }
 
 // ValidatorAlphaNumeric returns a validator that checks if a value contains only letters and numbers.
-func ValidatorAlphaNumeric() Validator {
-	return ValidatorPattern(`^[a-zA-Z0-9]+$`, "must contain only letters and numbers")
+func ValidatorAlphaNumeric() Rule {
+	return patternRule(RuleAlphaNumeric, nil, alphaNumericPattern, "")
 }
 
+// alphaNumericPattern is the pattern checked by ValidatorAlphaNumeric.
+const alphaNumericPattern = `^[a-zA-Z0-9]+$`
+
 // ValidatorOneOf returns a validator that checks if a value is one of the allowed values.
-func ValidatorOneOf(allowed ...string) Validator {
-	return func(fieldName string, value string) *ValidationError {
+func ValidatorOneOf(allowed ...string) Rule {
+	params := map[string]string{"values": listParam(allowed)}
+	return newRule(RuleOneOf, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		if value == "" {
 			return nil
 		}
@@ -148,17 +188,14 @@ func ValidatorOneOf(allowed ...string) Validator {
 				return nil
 			}
 		}
-		return &ValidationError{
-			Field:   fieldName,
-			Message: fieldName + " must be one of the allowed values",
-		}
-	}
+		return ruleError(fieldName, RuleOneOf, params)
+	})
 }
 
 // ValidatorCustom returns a validator that uses a custom function.
 // The function receives the value and returns an error message if invalid, or empty string if valid.
-func ValidatorCustom(fn func(value string) string) Validator {
-	return func(fieldName string, value string) *ValidationError {
+func ValidatorCustom(fn func(value string) string) Rule {
+	return newRule(RuleCustom, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
 		if msg := fn(value); msg != "" {
 			return &ValidationError{
 				Field:   fieldName,
@@ -166,46 +203,169 @@ func ValidatorCustom(fn func(value string) string) Validator {
 			}
 		}
 		return nil
-	}
+	})
 }
 
 // Validate validates the given values against the form fields and their validators.
 // It returns a slice of ValidationError. An empty slice means validation passed.
 // Errors are also stored on the form for inline display when Build() is called.
+//
+// Values are cleaned up by the fields' sanitizers before they are validated.
+// Fields nested inside field rows are validated too. The fields of each repeater
+// item are validated against the item's values, e.g. "addresses[city][2]",
+// and their errors are keyed by that same input name.
 func (form *Form) Validate(values map[string]string) []ValidationError {
 	var errors []ValidationError
 
-	for _, field := range form.fields {
-		f, ok := field.(*Field)
-		if !ok {
-			continue
-		}
+	for _, target := range validationTargets(form.fields, values) {
+		errors = append(errors, form.validateField(target)...)
+	}
 
-		value := values[f.Name]
+	form.storeErrors(errors)
 
-		if f.Required && strings.TrimSpace(value) == "" {
-			errors = append(errors, ValidationError{
-				Field:   f.Name,
-				Message: f.Name + " is required",
-			})
+	return errors
+}
+
+// storeErrors stores the error messages of each field for inline display.
+func (form *Form) storeErrors(errors []ValidationError) {
+	errorMap := make(map[string][]string)
+	for _, e := range errors {
+		errorMap[e.Field] = append(errorMap[e.Field], e.Message)
+	}
+	form.errors = errorMap
+}
+
+// validationTarget is a single value to validate against a field.
+type validationTarget struct {
+	field  FieldInterface
+	key    string            // field name, or input name for fields inside a repeater item
+	value  string            // submitted value
+	values map[string]string // all submitted values, passed on to cross-field validators
+}
+
+// validationTargets returns the values to validate for the fields,
+// recursing into rows and repeaters. The values are cleaned up by the
+// fields' sanitizers first.
+func validationTargets(fields []FieldInterface, values map[string]string) []validationTarget {
+	targets := []validationTarget{}
+	values = sanitizeValues(fields, values)
+
+	for _, field := range flattenFields(fields) {
+		if repeater, ok := field.(*fieldRepeater); ok {
+			targets = append(targets, repeaterValidationTargets(repeater, values)...)
 			continue
 		}
 
-		for _, validator := range f.Validators {
-			if err := validator(f.Name, value); err != nil {
-				errors = append(errors, *err)
+		// Each value selected in a multiple select is validated on its own
+		if f, ok := field.(*Field); ok && f.Multiple {
+			selected := splitSelected(values[f.Name])
+			if len(selected) == 0 {
+				selected = []string{""}
 			}
+			for _, value := range selected {
+				targets = append(targets, validationTarget{field: field, key: f.Name, value: value, values: values})
+			}
+			continue
 		}
+
+		targets = append(targets, validationTarget{
+			field:  field,
+			key:    field.GetName(),
+			value:  values[field.GetName()],
+			values: values,
+		})
 	}
 
-	// Store errors for inline display
-	errorMap := make(map[string]string)
-	for _, e := range errors {
-		if _, exists := errorMap[e.Field]; !exists {
-			errorMap[e.Field] = e.Message
+	return targets
+}
+
+// repeaterValidationTargets returns the values to validate for the fields of
+// every submitted repeater item.
+func repeaterValidationTargets(repeater *fieldRepeater, values map[string]string) []validationTarget {
+	targets := []validationTarget{}
+
+	itemFields := flattenFields(repeater.fields)
+
+	for _, itemIndex := range repeaterItemIndexes(repeater.GetName(), values) {
+		// Cross-field validators of an item look up the other fields of the same item
+		itemValues := make(map[string]string, len(values)+len(itemFields))
+		for key, value := range values {
+			itemValues[key] = value
+		}
+		for _, itemField := range itemFields {
+			key := repeaterItemFieldName(repeater.GetName(), itemField.GetName(), itemIndex)
+			itemValues[itemField.GetName()] = values[key]
+		}
+
+		for _, itemField := range itemFields {
+			key := repeaterItemFieldName(repeater.GetName(), itemField.GetName(), itemIndex)
+			targets = append(targets, validationTarget{
+				field:  itemField,
+				key:    key,
+				value:  values[key],
+				values: itemValues,
+			})
+		}
+	}
+
+	return targets
+}
+
+// validateField validates a single value against the field's required flag and,
+// for *Field, its validators. The errors are reported under the target's key,
+// with their messages in the form's locale.
+func (form *Form) validateField(target validationTarget) []ValidationError {
+	field := target.field
+
+	if f, ok := field.(*Field); ok && f.Signed && f.invalidSignatures[target.key] {
+		err := ruleError(target.key, RuleSignature, nil)
+		form.localize(err, field)
+		return []ValidationError{*err}
+	}
+
+	if field.GetRequired() && strings.TrimSpace(target.value) == "" {
+		err := ruleError(target.key, RuleRequired, nil)
+		form.localize(err, field)
+		return []ValidationError{*err}
+	}
+
+	f, ok := field.(*Field)
+	if !ok {
+		return nil
+	}
+
+	var errors []ValidationError
+	for _, rule := range f.Validators {
+		if err := rule.Validate(f.Name, target.value, target.values); err != nil {
+			err.Field = target.key
+			form.localize(err, field)
+			errors = append(errors, *err)
 		}
 	}
-	form.errors = errorMap
 
 	return errors
 }
+
+// repeaterItemIndexes returns the sorted item indexes present in the values
+// under the "repeaterName[fieldName][itemIndex]" keys.
+func repeaterItemIndexes[V any](repeaterName string, values map[string]V) []int {
+	prefix := repeaterName + `[`
+	seen := map[int]bool{}
+	indexes := []int{}
+
+	for key := range values {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		_, index, ok := splitRepeaterKey(key[len(prefix):])
+		if !ok || index < 0 || seen[index] {
+			continue
+		}
+		seen[index] = true
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+
+	return indexes
+}
@@ -0,0 +1,141 @@
+package form
+
+import (
+	"context"
+	"sync"
+)
+
+// ContextValidator is a validator that may call out to slow or external
+// services, such as a database lookup checking that an email is not taken.
+//
+// It returns a ValidationError when the value is invalid, and an error when
+// the check itself failed (for example, the database is unreachable).
+type ContextValidator func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error)
+
+// ValidateContext validates the values like Validate, then runs the fields'
+// context validators. Context validators are skipped for fields that already
+// failed validation, so expensive lookups only run for otherwise valid values.
+//
+// If a context validator returns an error, or the context is done, validation
+// stops and the error is returned; the form's inline errors are left unchanged.
+//
+// Context validators run one at a time, unless concurrency is enabled with
+// WithValidationConcurrency.
+func (form *Form) ValidateContext(ctx context.Context, values map[string]string) ([]ValidationError, error) {
+	var errors []ValidationError
+	jobs := []contextValidationJob{}
+
+	for _, target := range validationTargets(form.fields, values) {
+		fieldErrors := form.validateField(target)
+		if len(fieldErrors) > 0 {
+			errors = append(errors, fieldErrors...)
+			continue
+		}
+
+		f, ok := target.field.(*Field)
+		if !ok {
+			continue
+		}
+
+		for _, validator := range f.ContextValidators {
+			jobs = append(jobs, contextValidationJob{target: target, validator: validator})
+		}
+	}
+
+	results, err := runContextValidators(ctx, jobs, form.validationConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, result := range results {
+		if result != nil {
+			form.localize(result, jobs[i].target.field)
+			errors = append(errors, *result)
+		}
+	}
+
+	form.storeErrors(errors)
+
+	return errors, nil
+}
+
+// contextValidationJob is a context validator to run against a validation target.
+type contextValidationJob struct {
+	target    validationTarget
+	validator ContextValidator
+}
+
+func (job contextValidationJob) run(ctx context.Context) (*ValidationError, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f := job.target.field.(*Field)
+
+	result, err := job.validator(ctx, f.Name, job.target.value, job.target.values)
+	if err != nil {
+		return nil, err
+	}
+
+	if result != nil {
+		result.Field = job.target.key
+	}
+
+	return result, nil
+}
+
+// runContextValidators runs the jobs, at most concurrency at a time, and returns
+// their results in job order. The first error cancels the remaining jobs.
+func runContextValidators(ctx context.Context, jobs []contextValidationJob, concurrency int) ([]*ValidationError, error) {
+	results := make([]*ValidationError, len(jobs))
+
+	if concurrency <= 1 {
+		for i, job := range jobs {
+			result, err := job.run(ctx)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+		}
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	semaphore := make(chan struct{}, concurrency)
+
+	for i, job := range jobs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, job contextValidationJob) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result, err := job.run(ctx)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = result
+		}(i, job)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return results, nil
+}
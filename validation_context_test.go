@@ -0,0 +1,174 @@
+package form
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeUserStore is an in-memory stand-in for a database of registered emails.
+type fakeUserStore struct {
+	mu     sync.Mutex
+	emails map[string]bool
+	err    error
+	calls  int
+}
+
+func (store *fakeUserStore) emailTaken(ctx context.Context, email string) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.calls++
+	if store.err != nil {
+		return false, store.err
+	}
+	return store.emails[email], nil
+}
+
+func validatorUniqueEmail(store *fakeUserStore) ContextValidator {
+	return func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+		taken, err := store.emailTaken(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return &ValidationError{Field: fieldName, Message: "email is already registered"}, nil
+		}
+		return nil, nil
+	}
+}
+
+func TestValidateContextUserError(t *testing.T) {
+	store := &fakeUserStore{emails: map[string]bool{"taken@example.com": true}}
+	f := New().WithFields(
+		NewEmailField("email", "Email").
+			WithRequired().
+			WithValidators(ValidatorEmail()).
+			WithContextValidators(validatorUniqueEmail(store)),
+	)
+
+	errs, err := f.ValidateContext(context.Background(), map[string]string{"email": "taken@example.com"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(errs) != 1 || errs[0].Field != "email" || errs[0].Message != "email is already registered" {
+		t.Fatal("Unexpected validation errors:", errs)
+	}
+	if f.GetErrors()["email"] != "email is already registered" {
+		t.Fatal("Expected error to be stored for inline display, got:", f.GetErrors())
+	}
+
+	errs, err = f.ValidateContext(context.Background(), map[string]string{"email": "free@example.com"})
+	if err != nil || len(errs) != 0 {
+		t.Fatal("Expected validation to pass, got:", errs, err)
+	}
+}
+
+func TestValidateContextSkipsInvalidFields(t *testing.T) {
+	store := &fakeUserStore{}
+	f := New().WithFields(
+		NewEmailField("email", "Email").
+			WithValidators(ValidatorEmail()).
+			WithContextValidators(validatorUniqueEmail(store)),
+	)
+
+	errs, err := f.ValidateContext(context.Background(), map[string]string{"email": "not-an-email"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(errs) != 1 {
+		t.Fatal("Expected 1 error, got:", errs)
+	}
+	if store.calls != 0 {
+		t.Fatal("Expected context validator to be skipped, got calls:", store.calls)
+	}
+}
+
+func TestValidateContextInfrastructureError(t *testing.T) {
+	dbErr := errors.New("database unavailable")
+	store := &fakeUserStore{err: dbErr}
+	f := New().WithFields(
+		NewEmailField("email", "Email").WithContextValidators(validatorUniqueEmail(store)),
+	).WithErrors(map[string]string{"email": "previous"})
+
+	errs, err := f.ValidateContext(context.Background(), map[string]string{"email": "a@example.com"})
+	if !errors.Is(err, dbErr) {
+		t.Fatal("Expected database error, got:", err)
+	}
+	if errs != nil {
+		t.Fatal("Expected no validation errors, got:", errs)
+	}
+	if f.GetErrors()["email"] != "previous" {
+		t.Fatal("Expected inline errors to be left unchanged, got:", f.GetErrors())
+	}
+}
+
+func TestValidateContextCanceled(t *testing.T) {
+	store := &fakeUserStore{}
+	f := New().WithFields(
+		NewEmailField("email", "Email").WithContextValidators(validatorUniqueEmail(store)),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := f.ValidateContext(ctx, map[string]string{"email": "a@example.com"}); !errors.Is(err, context.Canceled) {
+		t.Fatal("Expected context.Canceled, got:", err)
+	}
+}
+
+func TestValidateContextConcurrent(t *testing.T) {
+	var running, maxRunning int32
+
+	slow := func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		if value == "bad" {
+			return &ValidationError{Field: fieldName, Message: "bad value"}, nil
+		}
+		return nil, nil
+	}
+
+	f := New().WithValidationConcurrency(2).WithFields(
+		NewStringField("a", "A").WithContextValidators(slow),
+		NewStringField("b", "B").WithContextValidators(slow),
+		NewStringField("c", "C").WithContextValidators(slow),
+		NewStringField("d", "D").WithContextValidators(slow),
+	)
+
+	errs, err := f.ValidateContext(context.Background(), map[string]string{"a": "ok", "b": "bad", "c": "ok", "d": "bad"})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(errs) != 2 || errs[0].Field != "b" || errs[1].Field != "d" {
+		t.Fatal("Expected errors on b and d in field order, got:", errs)
+	}
+	if maxRunning != 2 {
+		t.Fatal("Expected 2 validators to run at once, got:", maxRunning)
+	}
+}
+
+func TestValidateContextConcurrentError(t *testing.T) {
+	dbErr := errors.New("timeout")
+	failing := func(ctx context.Context, fieldName string, value string, values map[string]string) (*ValidationError, error) {
+		return nil, dbErr
+	}
+
+	f := New().WithValidationConcurrency(4).WithFields(
+		NewStringField("a", "A").WithContextValidators(failing),
+		NewStringField("b", "B").WithContextValidators(failing),
+	)
+
+	if _, err := f.ValidateContext(context.Background(), map[string]string{}); !errors.Is(err, dbErr) {
+		t.Fatal("Expected timeout error, got:", err)
+	}
+}
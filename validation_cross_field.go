@@ -0,0 +1,137 @@
+package form
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// FormValidator is a validator that receives all submitted form values,
+// so it can compare the field's value with the values of other fields.
+type FormValidator func(fieldName string, value string, values map[string]string) *ValidationError
+
+// ValidatorCrossField adapts a FormValidator to a Rule, so it can be used
+// in Field.Validators and WithValidators. When the rule is run
+// without the submitted values, an empty map is passed to the function.
+func ValidatorCrossField(fn FormValidator) Rule {
+	return crossFieldRule(RuleCrossField, nil, fn)
+}
+
+// crossFieldRule returns a rule with the name and params running the FormValidator.
+func crossFieldRule(name string, params map[string]string, fn FormValidator) Rule {
+	return newRule(name, params, func(fieldName string, value string, values map[string]string) *ValidationError {
+		if values == nil {
+			values = map[string]string{}
+		}
+		return fn(fieldName, value, values)
+	})
+}
+
+// ValidatorSameAs returns a validator that checks if a value equals the value
+// of another field, e.g. a password confirmation.
+func ValidatorSameAs(otherFieldName string) Rule {
+	params := map[string]string{"other": otherFieldName}
+	return crossFieldRule(RuleSameAs, params, func(fieldName string, value string, values map[string]string) *ValidationError {
+		if value != values[otherFieldName] {
+			return ruleError(fieldName, RuleSameAs, params)
+		}
+		return nil
+	})
+}
+
+// ValidatorGreaterThanField returns a validator that checks if a value is greater
+// than the value of another field. Numbers are compared numerically, dates and
+// datetimes chronologically, other values as strings.
+// The check is skipped when either value is empty.
+func ValidatorGreaterThanField(otherFieldName string) Rule {
+	params := map[string]string{"other": otherFieldName}
+	return crossFieldRule(RuleGreaterThanField, params, func(fieldName string, value string, values map[string]string) *ValidationError {
+		other := values[otherFieldName]
+		if value == "" || other == "" {
+			return nil
+		}
+
+		if compareValues(value, other) <= 0 {
+			return ruleError(fieldName, RuleGreaterThanField, params)
+		}
+		return nil
+	})
+}
+
+// ValidatorRequiredIf returns a validator that requires a value when the other
+// field has one of the given values. Without values, the field is required
+// whenever the other field is not empty.
+func ValidatorRequiredIf(otherFieldName string, otherValues ...string) Rule {
+	return crossFieldRule(RuleRequiredIf, conditionParams(otherFieldName, otherValues), func(fieldName string, value string, values map[string]string) *ValidationError {
+		if !otherFieldMatches(values[otherFieldName], otherValues) {
+			return nil
+		}
+
+		if strings.TrimSpace(value) == "" {
+			return ruleError(fieldName, RuleRequired, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorRequiredUnless returns a validator that requires a value unless the
+// other field has one of the given values. Without values, the field is required
+// unless the other field is not empty.
+func ValidatorRequiredUnless(otherFieldName string, otherValues ...string) Rule {
+	return crossFieldRule(RuleRequiredUnless, conditionParams(otherFieldName, otherValues), func(fieldName string, value string, values map[string]string) *ValidationError {
+		if otherFieldMatches(values[otherFieldName], otherValues) {
+			return nil
+		}
+
+		if strings.TrimSpace(value) == "" {
+			return ruleError(fieldName, RuleRequired, nil)
+		}
+		return nil
+	})
+}
+
+// conditionParams returns the params of a conditional rule: the other field
+// and, if any, the values it is compared with.
+func conditionParams(otherFieldName string, otherValues []string) map[string]string {
+	params := map[string]string{"other": otherFieldName}
+	if len(otherValues) > 0 {
+		params["values"] = listParam(otherValues)
+	}
+	return params
+}
+
+// otherFieldMatches reports whether the other field's value is one of the given
+// values or, when no values are given, whether it is not empty.
+func otherFieldMatches(otherValue string, otherValues []string) bool {
+	if len(otherValues) == 0 {
+		return strings.TrimSpace(otherValue) != ""
+	}
+	return lo.Contains(otherValues, otherValue)
+}
+
+// compareValues compares two values as numbers, as dates or datetimes,
+// or else as strings. It returns -1, 0 or 1.
+func compareValues(a string, b string) int {
+	if x, errA := strconv.ParseFloat(a, 64); errA == nil {
+		if y, errB := strconv.ParseFloat(b, 64); errB == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	for _, fieldType := range []string{FORM_FIELD_TYPE_DATE, FORM_FIELD_TYPE_DATETIME} {
+		x, errA := parseFieldTime(fieldType, a)
+		y, errB := parseFieldTime(fieldType, b)
+		if errA == nil && errB == nil {
+			return x.Compare(y)
+		}
+	}
+
+	return strings.Compare(a, b)
+}
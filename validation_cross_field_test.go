@@ -0,0 +1,114 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestValidatorSameAs(t *testing.T) {
+	f := New().WithFields(
+		NewPasswordField("password", "Password"),
+		NewPasswordField("password_confirm", "Confirm").WithValidators(ValidatorSameAs("password")),
+	)
+
+	AssertValidationPasses(t, f, map[string]string{"password": "secret", "password_confirm": "secret"})
+	AssertValidationFailsOn(t, f, map[string]string{"password": "secret", "password_confirm": "other"}, "password_confirm")
+}
+
+func TestValidatorGreaterThanFieldNumbers(t *testing.T) {
+	f := New().WithFields(
+		NewNumberField("min", "Min"),
+		NewNumberField("max", "Max").WithValidators(ValidatorGreaterThanField("min")),
+	)
+
+	AssertValidationPasses(t, f, map[string]string{"min": "9", "max": "10"})
+	AssertValidationFailsOn(t, f, map[string]string{"min": "10", "max": "9"}, "max")
+	AssertValidationFailsOn(t, f, map[string]string{"min": "10", "max": "10"}, "max")
+	AssertValidationPasses(t, f, map[string]string{"min": "10", "max": ""})
+}
+
+func TestValidatorGreaterThanFieldDates(t *testing.T) {
+	f := New().WithFields(
+		NewDateField("start", "Start"),
+		NewDateField("end", "End").WithValidators(ValidatorGreaterThanField("start")),
+	)
+
+	AssertValidationPasses(t, f, map[string]string{"start": "2024-01-31", "end": "2024-02-01"})
+	AssertValidationFailsOn(t, f, map[string]string{"start": "2024-02-01", "end": "2024-01-31"}, "end")
+
+	f = New().WithFields(
+		NewDateTimeField("start", "Start"),
+		NewDateTimeField("end", "End").WithValidators(ValidatorGreaterThanField("start")),
+	)
+
+	AssertValidationPasses(t, f, map[string]string{"start": "2024-01-31T09:00", "end": "2024-01-31T10:00"})
+	AssertValidationFailsOn(t, f, map[string]string{"start": "2024-01-31T10:00", "end": "2024-01-31T09:00"}, "end")
+}
+
+func TestValidatorRequiredIf(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("country", "Country"),
+		NewStringField("vat", "VAT").WithValidators(ValidatorRequiredIf("country", "DE", "FR", "BG")),
+	)
+
+	AssertValidationFailsOn(t, f, map[string]string{"country": "DE", "vat": ""}, "vat")
+	AssertValidationPasses(t, f, map[string]string{"country": "DE", "vat": "DE123"})
+	AssertValidationPasses(t, f, map[string]string{"country": "US", "vat": ""})
+}
+
+func TestValidatorRequiredIfAnyValue(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("company", "Company"),
+		NewStringField("vat", "VAT").WithValidators(ValidatorRequiredIf("company")),
+	)
+
+	AssertValidationFailsOn(t, f, map[string]string{"company": "ACME", "vat": ""}, "vat")
+	AssertValidationPasses(t, f, map[string]string{"company": "", "vat": ""})
+}
+
+func TestValidatorRequiredUnless(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("delivery", "Delivery"),
+		NewStringField("address", "Address").WithValidators(ValidatorRequiredUnless("delivery", "pickup")),
+	)
+
+	AssertValidationPasses(t, f, map[string]string{"delivery": "pickup", "address": ""})
+	AssertValidationFailsOn(t, f, map[string]string{"delivery": "courier", "address": ""}, "address")
+	AssertValidationPasses(t, f, map[string]string{"delivery": "courier", "address": "1 Main St"})
+}
+
+func TestValidatorCrossFieldWithoutValues(t *testing.T) {
+	v := ValidatorSameAs("password")
+
+	if err := v.Validate("confirm", "", nil); err != nil {
+		t.Fatal("Expected empty value to match missing field, got:", err.Message)
+	}
+	if err := v.Validate("confirm", "secret", nil); err == nil {
+		t.Fatal("Expected error when the other value is not available")
+	}
+}
+
+func TestValidatorCrossFieldInRepeaterItem(t *testing.T) {
+	repeater := NewRepeater(RepeaterOptions{
+		Name: "periods",
+		Fields: []FieldInterface{
+			NewNumberField("from", "From"),
+			NewNumberField("to", "To").WithValidators(ValidatorGreaterThanField("from")),
+		},
+		RepeaterAddUrl:    "/add",
+		RepeaterRemoveUrl: "/remove",
+	})
+	f := New().WithFields(repeater)
+
+	values := f.ParseValues(url.Values{
+		"periods[from][0]": {"1"},
+		"periods[to][0]":   {"5"},
+		"periods[from][1]": {"7"},
+		"periods[to][1]":   {"3"},
+	})
+
+	errs := f.Validate(values)
+	if len(errs) != 1 || errs[0].Field != "periods[to][1]" {
+		t.Fatal("Expected one error on the second item, got:", errs)
+	}
+}
@@ -0,0 +1,362 @@
+package form
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRef is a date that date validators compare values with: a fixed date,
+// or a date relative to the current day or time, read from a clock.
+type DateRef struct {
+	fixed  time.Time
+	base   string // "" for a fixed date, dateRefToday or dateRefNow
+	days   int
+	offset time.Duration
+	clock  func() time.Time
+}
+
+const (
+	dateRefToday = "today"
+	dateRefNow   = "now"
+)
+
+// Layout of dates and times in validation messages.
+const dateTimeMessageLayout = "2006-01-02 15:04"
+
+// DateFixed returns a fixed date. Its wall clock time is compared with the
+// submitted values, whatever its location.
+func DateFixed(t time.Time) DateRef {
+	return DateRef{fixed: t}
+}
+
+// DateToday returns the current day moved by the number of days,
+// e.g. DateToday(1) for tomorrow and DateToday(-90) for 90 days ago.
+func DateToday(days int) DateRef {
+	return DateRef{base: dateRefToday, days: days}
+}
+
+// DateNow returns the current time moved by the offset,
+// e.g. DateNow(2 * time.Hour) for two hours from now.
+func DateNow(offset time.Duration) DateRef {
+	return DateRef{base: dateRefNow, offset: offset}
+}
+
+// WithClock returns the date with the clock that relative dates read the
+// current time from, time.Now by default. The clock is not stored in form
+// definitions.
+func (ref DateRef) WithClock(clock func() time.Time) DateRef {
+	ref.clock = clock
+	return ref
+}
+
+// Time returns the date as a wall clock time in UTC, the way submitted
+// values are parsed.
+func (ref DateRef) Time() time.Time {
+	switch ref.base {
+	case dateRefToday:
+		y, m, d := ref.now().Date()
+		return time.Date(y, m, d+ref.days, 0, 0, 0, 0, time.UTC)
+	case dateRefNow:
+		return wallClockUTC(ref.now()).Add(ref.offset)
+	}
+	return wallClockUTC(ref.fixed)
+}
+
+// String returns the date as stored in rule params: "2006-01-02" or
+// "2006-01-02T15:04" for fixed dates, "today", "today+90d", "now" or
+// "now-30m0s" for relative dates.
+func (ref DateRef) String() string {
+	switch ref.base {
+	case dateRefToday:
+		if ref.days == 0 {
+			return dateRefToday
+		}
+		return dateRefToday + offsetSign(ref.days >= 0) + strings.TrimPrefix(strconv.Itoa(ref.days), "-") + "d"
+	case dateRefNow:
+		if ref.offset == 0 {
+			return dateRefNow
+		}
+		return dateRefNow + offsetSign(ref.offset >= 0) + strings.TrimPrefix(ref.offset.String(), "-")
+	}
+
+	t := wallClockUTC(ref.fixed)
+	switch {
+	case t.Second() != 0:
+		return t.Format(dateTimeSecondsLayout)
+	case t.Hour() != 0 || t.Minute() != 0:
+		return t.Format(dateTimeLayout)
+	}
+	return t.Format(dateLayout)
+}
+
+func (ref DateRef) now() time.Time {
+	if ref.clock != nil {
+		return ref.clock()
+	}
+	return time.Now()
+}
+
+// parseDateRef parses a date in the format returned by DateRef.String.
+func parseDateRef(s string) (DateRef, error) {
+	invalid := errors.New("invalid date " + strconv.Quote(s))
+
+	for _, base := range []string{dateRefToday, dateRefNow} {
+		if !strings.HasPrefix(s, base) {
+			continue
+		}
+
+		offset := strings.TrimPrefix(s, base)
+		if offset == "" {
+			return DateRef{base: base}, nil
+		}
+
+		if offset[0] != '+' && offset[0] != '-' {
+			return DateRef{}, invalid
+		}
+
+		if base == dateRefToday {
+			days, err := strconv.Atoi(strings.TrimSuffix(offset, "d"))
+			if err != nil || !strings.HasSuffix(offset, "d") {
+				return DateRef{}, invalid
+			}
+			return DateToday(days), nil
+		}
+
+		duration, err := time.ParseDuration(offset)
+		if err != nil {
+			return DateRef{}, invalid
+		}
+		return DateNow(duration), nil
+	}
+
+	t, _, ok := parseDateValue(s)
+	if !ok {
+		return DateRef{}, invalid
+	}
+
+	return DateFixed(t), nil
+}
+
+// ValidatorDate returns a validator that checks if a value is a date in the
+// layout, e.g. "02.01.2006". An empty layout accepts the values submitted by
+// date and datetime inputs.
+func ValidatorDate(layout string) Rule {
+	var params map[string]string
+	if layout != "" {
+		params = map[string]string{"layout": layout}
+	}
+
+	return newRule(RuleDate, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+
+		valid := false
+		if layout != "" {
+			_, err := time.Parse(layout, value)
+			valid = err == nil
+		} else {
+			_, _, valid = parseDateValue(value)
+		}
+
+		if !valid {
+			return ruleError(fieldName, RuleDate, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorDateAfter returns a validator that checks if a date is on or after
+// the min date. Values of date inputs are compared with the day of the min date.
+func ValidatorDateAfter(min DateRef) Rule {
+	return dateRangeRule(RuleDateAfter, &min, nil)
+}
+
+// ValidatorDateBefore returns a validator that checks if a date is on or before
+// the max date. Values of date inputs are compared with the day of the max date.
+func ValidatorDateBefore(max DateRef) Rule {
+	return dateRangeRule(RuleDateBefore, nil, &max)
+}
+
+// ValidatorDateBetween returns a validator that checks if a date is on or
+// between the min and max dates.
+func ValidatorDateBetween(min DateRef, max DateRef) Rule {
+	return dateRangeRule(RuleDateBetween, &min, &max)
+}
+
+// ValidatorWeekday returns a validator that checks if a date falls on one of
+// the days, e.g. ValidatorWeekday(time.Monday, time.Tuesday, time.Wednesday,
+// time.Thursday, time.Friday) for business days.
+func ValidatorWeekday(days ...time.Weekday) Rule {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = strings.ToLower(day.String())
+	}
+	params := map[string]string{"days": listParam(names)}
+
+	return newRule(RuleWeekday, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+
+		t, _, ok := parseDateValue(value)
+		if !ok {
+			return ruleError(fieldName, RuleDate, nil)
+		}
+
+		for _, day := range days {
+			if t.Weekday() == day {
+				return nil
+			}
+		}
+		return ruleError(fieldName, RuleWeekday, params)
+	})
+}
+
+// dateRule is a date range rule, which keeps its dates so constraintAttrs
+// can derive the min and max attributes with their clocks.
+type dateRule struct {
+	Rule
+	min *DateRef
+	max *DateRef
+}
+
+// dateRangeRule returns a rule that checks if a date is within the dates;
+// a nil date is unbounded.
+func dateRangeRule(name string, min *DateRef, max *DateRef) Rule {
+	params := map[string]string{}
+	if min != nil {
+		params["min"] = min.String()
+	}
+	if max != nil {
+		params["max"] = max.String()
+	}
+
+	validate := func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+
+		t, dateOnly, ok := parseDateValue(value)
+		if !ok {
+			return ruleError(fieldName, RuleDate, nil)
+		}
+
+		minTime, maxTime := resolveDateRange(min, max, dateOnly)
+		if (min != nil && t.Before(minTime)) || (max != nil && t.After(maxTime)) {
+			return ruleError(fieldName, name, dateMessageParams(min, max, minTime, maxTime, dateOnly))
+		}
+		return nil
+	}
+
+	return &dateRule{
+		Rule: newRule(name, params, validate),
+		min:  min,
+		max:  max,
+	}
+}
+
+// constraintAttrs returns the min and max attributes of a date or datetime input.
+func (r *dateRule) constraintAttrs(fieldType string) map[string]string {
+	dateOnly := fieldType != FORM_FIELD_TYPE_DATETIME
+	layout := dateTimeLayout
+	if dateOnly {
+		layout = dateLayout
+	}
+
+	attrs := map[string]string{}
+	minTime, maxTime := resolveDateRange(r.min, r.max, dateOnly)
+	if r.min != nil {
+		attrs["min"] = minTime.Format(layout)
+	}
+	if r.max != nil {
+		attrs["max"] = maxTime.Format(layout)
+	}
+	return attrs
+}
+
+// resolveDateRange returns the times of the dates, truncated to their day
+// when compared with date values.
+func resolveDateRange(min *DateRef, max *DateRef, dateOnly bool) (time.Time, time.Time) {
+	var minTime, maxTime time.Time
+	if min != nil {
+		minTime = min.Time()
+	}
+	if max != nil {
+		maxTime = max.Time()
+	}
+	if dateOnly {
+		minTime = minTime.Truncate(24 * time.Hour)
+		maxTime = maxTime.Truncate(24 * time.Hour)
+	}
+	return minTime, maxTime
+}
+
+// dateMessageParams returns the min and max dates formatted for messages.
+func dateMessageParams(min *DateRef, max *DateRef, minTime time.Time, maxTime time.Time, dateOnly bool) map[string]string {
+	layout := dateTimeMessageLayout
+	if dateOnly {
+		layout = dateLayout
+	}
+
+	params := map[string]string{}
+	if min != nil {
+		params["min"] = minTime.Format(layout)
+	}
+	if max != nil {
+		params["max"] = maxTime.Format(layout)
+	}
+	return params
+}
+
+// parseDateValue parses a value submitted by a date or datetime input,
+// and reports whether it is a date without a time.
+func parseDateValue(value string) (t time.Time, dateOnly bool, ok bool) {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, true, true
+	}
+	for _, layout := range []string{dateTimeLayout, dateTimeSecondsLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// parseWeekdays parses the lowercase day names of the "days" param.
+func parseWeekdays(names string) ([]time.Weekday, error) {
+	list, err := parseListParam(names)
+	if err != nil {
+		return nil, err
+	}
+
+	days := []time.Weekday{}
+	for _, name := range list {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.ToLower(day.String()) == name {
+				days = append(days, day)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.New("invalid day " + strconv.Quote(name))
+		}
+	}
+	return days, nil
+}
+
+// wallClockUTC returns the wall clock time of t in UTC.
+func wallClockUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// offsetSign returns the sign of a relative date's offset.
+func offsetSign(positive bool) string {
+	if positive {
+		return "+"
+	}
+	return "-"
+}
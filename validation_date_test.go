@@ -0,0 +1,195 @@
+package form
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// testClock returns a clock fixed at 2024-03-15 (a Friday) 10:30 in Berlin.
+func testClock() func() time.Time {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	if berlin == nil {
+		berlin = time.FixedZone("CET", 3600)
+	}
+	return func() time.Time {
+		return time.Date(2024, 3, 15, 10, 30, 0, 0, berlin)
+	}
+}
+
+func TestValidatorDate(t *testing.T) {
+	tests := []struct {
+		rule  Rule
+		value string
+		valid bool
+	}{
+		{ValidatorDate(""), "2024-03-15", true},
+		{ValidatorDate(""), "2024-03-15T10:30", true},
+		{ValidatorDate(""), "2024-03-15T10:30:45", true},
+		{ValidatorDate(""), "", true},
+		{ValidatorDate(""), "2024-02-30", false},
+		{ValidatorDate(""), "15.03.2024", false},
+		{ValidatorDate("02.01.2006"), "15.03.2024", true},
+		{ValidatorDate("02.01.2006"), "2024-03-15", false},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate("d", test.value, nil)
+		if (err == nil) != test.valid {
+			t.Errorf("%v %q: expected valid %v, got %v", test.rule.Params(), test.value, test.valid, err)
+		}
+		if err != nil && err.Rule != RuleDate {
+			t.Errorf("Expected a date error, got %q", err.Rule)
+		}
+	}
+}
+
+func TestValidatorDateRanges(t *testing.T) {
+	clock := testClock()
+	fixed := DateFixed(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name  string
+		rule  Rule
+		value string
+		valid bool
+	}{
+		{"not before 1900", ValidatorDateAfter(fixed), "1900-01-01", true},
+		{"not before 1900", ValidatorDateAfter(fixed), "1899-12-31", false},
+		{"in the future", ValidatorDateAfter(DateToday(1).WithClock(clock)), "2024-03-16", true},
+		{"in the future", ValidatorDateAfter(DateToday(1).WithClock(clock)), "2024-03-15", false},
+		{"in the future", ValidatorDateAfter(DateToday(1).WithClock(clock)), "2024-03-15T23:59", false},
+		{"not in the past", ValidatorDateAfter(DateNow(0).WithClock(clock)), "2024-03-15T10:29", false},
+		{"not in the past", ValidatorDateAfter(DateNow(0).WithClock(clock)), "2024-03-15T10:30", true},
+		{"not in the past", ValidatorDateAfter(DateNow(0).WithClock(clock)), "2024-03-15", true},
+		{"within 90 days", ValidatorDateBetween(DateToday(0).WithClock(clock), DateToday(90).WithClock(clock)), "2024-06-13", true},
+		{"within 90 days", ValidatorDateBetween(DateToday(0).WithClock(clock), DateToday(90).WithClock(clock)), "2024-06-14", false},
+		{"within 90 days", ValidatorDateBetween(DateToday(0).WithClock(clock), DateToday(90).WithClock(clock)), "2024-03-14", false},
+		{"at most 2 hours ahead", ValidatorDateBefore(DateNow(2 * time.Hour).WithClock(clock)), "2024-03-15T12:30", true},
+		{"at most 2 hours ahead", ValidatorDateBefore(DateNow(2 * time.Hour).WithClock(clock)), "2024-03-15T12:31", false},
+		{"empty", ValidatorDateBefore(fixed), "", true},
+	}
+
+	for _, test := range tests {
+		if err := test.rule.Validate("d", test.value, nil); (err == nil) != test.valid {
+			t.Errorf("%s %q: expected valid %v, got %v", test.name, test.value, test.valid, err)
+		}
+	}
+
+	err := ValidatorDateAfter(DateToday(1).WithClock(clock)).Validate("d", "2024-03-01", nil)
+	if err == nil || err.Rule != RuleDateAfter || err.Message != "d must be on or after 2024-03-16" {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	err = ValidatorDateBetween(fixed, DateNow(0).WithClock(clock)).Validate("d", "2024-03-15T11:00", nil)
+	if err == nil || err.Message != "d must be between 1900-01-01 00:00 and 2024-03-15 10:30" {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if err := ValidatorDateAfter(fixed).Validate("d", "yesterday", nil); err == nil || err.Rule != RuleDate {
+		t.Fatal("Expected a date error, got:", err)
+	}
+}
+
+func TestValidatorWeekday(t *testing.T) {
+	businessDays := ValidatorWeekday(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday)
+
+	if businessDays.Params()["days"] != `["monday","tuesday","wednesday","thursday","friday"]` {
+		t.Fatal("Unexpected params:", businessDays.Params())
+	}
+
+	if err := businessDays.Validate("d", "2024-03-15T10:30", nil); err != nil {
+		t.Fatal("Expected Friday to pass, got:", err)
+	}
+
+	if err := businessDays.Validate("d", "2024-03-16", nil); err == nil || err.Rule != RuleWeekday {
+		t.Fatal("Expected Saturday to fail, got:", err)
+	}
+}
+
+func TestDateRefString(t *testing.T) {
+	tests := map[string]DateRef{
+		"today":            DateToday(0),
+		"today+90d":        DateToday(90),
+		"today-1d":         DateToday(-1),
+		"now":              DateNow(0),
+		"now+2h0m0s":       DateNow(2 * time.Hour),
+		"now-30m0s":        DateNow(-30 * time.Minute),
+		"1900-01-01":       DateFixed(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)),
+		"2024-03-15T09:30": DateFixed(time.Date(2024, 3, 15, 9, 30, 0, 0, time.FixedZone("X", 7200))),
+	}
+
+	for expected, ref := range tests {
+		if ref.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, ref.String())
+		}
+
+		parsed, err := parseDateRef(expected)
+		if err != nil || parsed.String() != expected {
+			t.Errorf("Expected %q to parse back, got %q %v", expected, parsed.String(), err)
+		}
+	}
+
+	for _, invalid := range []string{"tomorrow", "today+1", "now+1x", "todayx", "2024-13-01"} {
+		if _, err := parseDateRef(invalid); err == nil {
+			t.Errorf("Expected %q to be invalid", invalid)
+		}
+	}
+}
+
+func TestDateRuleConstraintAttrs(t *testing.T) {
+	clock := testClock()
+
+	date := NewDateField("arrival", "Arrival").WithValidators(
+		ValidatorDateBetween(DateToday(1).WithClock(clock), DateToday(90).WithClock(clock)),
+	)
+	html := date.BuildFormGroup("").ToHTML()
+	if !strings.Contains(html, `min="2024-03-16"`) || !strings.Contains(html, `max="2024-06-13"`) {
+		t.Fatal("Expected date min and max attributes, got:", html)
+	}
+
+	datetime := NewDateTimeField("meeting", "Meeting").WithValidators(
+		ValidatorDateAfter(DateNow(time.Hour).WithClock(clock)),
+	)
+	html = datetime.BuildFormGroup("").ToHTML()
+	if !strings.Contains(html, `min="2024-03-15T11:30"`) || strings.Contains(html, `max=`) {
+		t.Fatal("Expected a datetime min attribute, got:", html)
+	}
+
+	text := NewStringField("note", "Note").WithValidators(ValidatorDateAfter(DateToday(0)))
+	if html := text.BuildFormGroup("").ToHTML(); strings.Contains(html, `min=`) {
+		t.Fatal("Expected no min attribute on a text input, got:", html)
+	}
+}
+
+func TestDateRulesDefinition(t *testing.T) {
+	f := New().WithFields(
+		NewDateField("arrival", "Arrival").WithValidators(
+			ValidatorDate(""),
+			ValidatorDateAfter(DateToday(1)),
+			ValidatorDateBefore(DateFixed(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC))),
+			ValidatorDateBetween(DateNow(-time.Hour), DateToday(90)),
+			ValidatorWeekday(time.Saturday, time.Sunday),
+		),
+		NewStringField("birthday", "Birthday").WithValidators(ValidatorDate("02.01.2006")),
+	)
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := loaded.MarshalDefinition()
+	if err != nil || string(again) != string(data) {
+		t.Fatalf("Expected the date rules to round-trip, got:\n%s\n%v", again, err)
+	}
+
+	if _, ok := loaded.findField("arrival").(*Field).Validators[1].(*dateRule); !ok {
+		t.Fatal("Expected a loaded date rule to emit constraint attributes")
+	}
+}
@@ -0,0 +1,346 @@
+package form
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/mail"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Card brands detected by CardBrand.
+const (
+	CardBrandAmex       = "amex"
+	CardBrandDiners     = "diners"
+	CardBrandDiscover   = "discover"
+	CardBrandJCB        = "jcb"
+	CardBrandMaestro    = "maestro"
+	CardBrandMastercard = "mastercard"
+	CardBrandUnionPay   = "unionpay"
+	CardBrandVisa       = "visa"
+)
+
+// ValidatorIPv6 returns a validator that checks if a value is a valid IPv6 address.
+func ValidatorIPv6() Rule {
+	return newRule(RuleIPv6, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return ruleError(fieldName, RuleIPv6, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorCIDR returns a validator that checks if a value is an IPv4 or IPv6
+// address with a prefix length, e.g. "192.168.0.0/16".
+func ValidatorCIDR() Rule {
+	return newRule(RuleCIDR, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+		if _, err := netip.ParsePrefix(value); err != nil {
+			return ruleError(fieldName, RuleCIDR, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorHostname returns a validator that checks if a value is a valid
+// host name (RFC 1123), e.g. "mail.example.com".
+func ValidatorHostname() Rule {
+	return newRule(RuleHostname, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value != "" && !isHostname(value) {
+			return ruleError(fieldName, RuleHostname, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorE164 returns a validator that checks if a value is a phone number
+// in the international E.164 format, e.g. "+4930123456".
+func ValidatorE164() Rule {
+	return patternRule(RuleE164, nil, `^\+[1-9][0-9]{1,14}$`, "")
+}
+
+// ValidatorCreditCard returns a validator that checks if a value is a card
+// number of a known brand with a valid Luhn checksum. Spaces and hyphens are
+// ignored. When brands are given, e.g. CardBrandVisa, only cards of those
+// brands are accepted.
+func ValidatorCreditCard(brands ...string) Rule {
+	var params map[string]string
+	if len(brands) > 0 {
+		params = map[string]string{"brands": listParam(brands)}
+	}
+
+	return newRule(RuleCreditCard, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+
+		number := stripSeparators(value)
+		brand := CardBrand(number)
+		if brand == "" || !isDigits(number) || !luhnValid(number) {
+			return ruleError(fieldName, RuleCreditCard, nil)
+		}
+
+		if len(brands) == 0 {
+			return nil
+		}
+		for _, allowed := range brands {
+			if brand == allowed {
+				return nil
+			}
+		}
+		return ruleError(fieldName, RuleCreditCardBrand, params)
+	})
+}
+
+// CardBrand returns the brand of a card number, detected by its prefix and
+// length, e.g. CardBrandVisa, or "" if unknown. Spaces and hyphens are ignored.
+// The checksum is not verified.
+func CardBrand(number string) string {
+	number = stripSeparators(number)
+	if !isDigits(number) {
+		return ""
+	}
+
+	length := len(number)
+	prefix := func(digits int) int {
+		if length < digits {
+			return -1
+		}
+		n := 0
+		for _, r := range number[:digits] {
+			n = n*10 + int(r-'0')
+		}
+		return n
+	}
+	between := func(n int, from int, to int) bool {
+		return n >= from && n <= to
+	}
+
+	switch {
+	case (prefix(2) == 34 || prefix(2) == 37) && length == 15:
+		return CardBrandAmex
+	case prefix(1) == 4 && (length == 13 || length == 16 || length == 19):
+		return CardBrandVisa
+	case (between(prefix(2), 51, 55) || between(prefix(4), 2221, 2720)) && length == 16:
+		return CardBrandMastercard
+	case (prefix(4) == 6011 || between(prefix(3), 644, 649) || prefix(2) == 65) && between(length, 16, 19):
+		return CardBrandDiscover
+	case between(prefix(4), 3528, 3589) && between(length, 16, 19):
+		return CardBrandJCB
+	case (between(prefix(3), 300, 305) || prefix(2) == 36 || prefix(2) == 38 || prefix(2) == 39) && between(length, 14, 19):
+		return CardBrandDiners
+	case prefix(2) == 62 && between(length, 16, 19):
+		return CardBrandUnionPay
+	case (prefix(2) == 50 || between(prefix(2), 56, 58) || prefix(4) == 6304 || prefix(4) == 6759 || prefix(6) == 676770 || prefix(6) == 676774) && between(length, 12, 19):
+		return CardBrandMaestro
+	}
+
+	return ""
+}
+
+// ValidatorIBAN returns a validator that checks if a value is an IBAN with a
+// valid checksum and, for known countries, length. Spaces are ignored.
+func ValidatorIBAN() Rule {
+	return newRule(RuleIBAN, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value != "" && !isIBAN(value) {
+			return ruleError(fieldName, RuleIBAN, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorISBN returns a validator that checks if a value is an ISBN-10 or
+// ISBN-13 with a valid check digit. Spaces and hyphens are ignored.
+func ValidatorISBN() Rule {
+	return newRule(RuleISBN, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value != "" && !isISBN(stripSeparators(value)) {
+			return ruleError(fieldName, RuleISBN, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorSemver returns a validator that checks if a value is a semantic
+// version (semver.org), e.g. "1.4.0-beta.1".
+func ValidatorSemver() Rule {
+	return patternRule(RuleSemver, nil, semverPattern, "")
+}
+
+// semverPattern is the pattern recommended by semver.org.
+const semverPattern = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+
+// ValidatorSlug returns a validator that checks if a value is a URL slug of
+// lowercase letters and digits separated by single hyphens, e.g. "my-post-2".
+func ValidatorSlug() Rule {
+	return patternRule(RuleSlug, nil, `^[a-z0-9]+(?:-[a-z0-9]+)*$`, "")
+}
+
+// ValidatorJSON returns a validator that checks if a value is a valid JSON document.
+func ValidatorJSON() Rule {
+	return newRule(RuleJSON, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value != "" && !json.Valid([]byte(value)) {
+			return ruleError(fieldName, RuleJSON, nil)
+		}
+		return nil
+	})
+}
+
+// ValidatorHexColor returns a validator that checks if a value is a hex
+// color, e.g. "#ff8800" as submitted by color inputs, or "#f80".
+func ValidatorHexColor() Rule {
+	return patternRule(RuleHexColor, nil, `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`, "")
+}
+
+// ValidatorEmailAddress returns a validator that checks if a value is an
+// email address as parsed by net/mail (RFC 5322), without a display name.
+// It fails with the "email" message.
+func ValidatorEmailAddress() Rule {
+	return newRule(RuleEmailAddress, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if value == "" {
+			return nil
+		}
+		address, err := mail.ParseAddress(value)
+		// Angle brackets and comments are part of a header, not of an address
+		if err != nil || address.Name != "" || strings.ContainsAny(value, "<>()") || strings.TrimSpace(value) != value {
+			return ruleError(fieldName, RuleEmail, nil)
+		}
+		return nil
+	})
+}
+
+// isHostname reports whether the value is a host name of dot separated
+// labels of letters, digits and inner hyphens.
+func isHostname(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if value == "" || len(value) > 253 {
+		return false
+	}
+
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// luhnValid reports whether the digits have a valid Luhn checksum.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ibanLengths are the IBAN lengths of the countries in the IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20,
+	"LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24, "PL": 28,
+	"PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24, "SC": 31,
+	"SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// isIBAN reports whether the value is an IBAN with a valid mod 97 checksum.
+func isIBAN(value string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(value, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	for i, r := range iban {
+		letter := r >= 'A' && r <= 'Z'
+		digit := r >= '0' && r <= '9'
+		if (i < 2 && !letter) || (i >= 2 && i < 4 && !digit) || (!letter && !digit) {
+			return false
+		}
+	}
+
+	if length, known := ibanLengths[iban[:2]]; known && len(iban) != length {
+		return false
+	}
+
+	// Move the country code and check digits to the end, and replace letters with 10 to 35
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// isISBN reports whether the value is an ISBN-10 or ISBN-13 with a valid check digit.
+func isISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, r := range isbn {
+			var d int
+			switch {
+			case r >= '0' && r <= '9':
+				d = int(r - '0')
+			case (r == 'X' || r == 'x') && i == 9:
+				d = 10
+			default:
+				return false
+			}
+			sum += (10 - i) * d
+		}
+		return sum%11 == 0
+	case 13:
+		if !isDigits(isbn) || !(strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) {
+			return false
+		}
+		sum := 0
+		for i, r := range isbn {
+			weight := 1
+			if i%2 == 1 {
+				weight = 3
+			}
+			sum += weight * int(r-'0')
+		}
+		return sum%10 == 0
+	}
+	return false
+}
+
+// stripSeparators removes the spaces and hyphens used to group digits.
+func stripSeparators(value string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(value)
+}
@@ -0,0 +1,142 @@
+package form
+
+import (
+	"testing"
+)
+
+func TestFormatValidators(t *testing.T) {
+	tests := []struct {
+		rule    Rule
+		valid   []string
+		invalid []string
+	}{
+		{ValidatorIPv6(), []string{"::1", "2001:db8::8a2e:370:7334", "::ffff:192.0.2.1"}, []string{"192.168.0.1", "fe80::1%eth0", "2001:db8::g", "[::1]"}},
+		{ValidatorCIDR(), []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"}, []string{"10.0.0.0", "10.0.0.0/33", "example.com/8"}},
+		{ValidatorHostname(), []string{"localhost", "mail.example.com", "example.com.", "xn--bcher-kva.de", "1e100.net"}, []string{"-example.com", "example-.com", "exa_mple.com", "a..b", "."}},
+		{ValidatorE164(), []string{"+4930123456", "+14155552671"}, []string{"030123456", "+0301234", "+49 30 123456", "+1234567890123456"}},
+		{ValidatorIBAN(), []string{"DE89370400440532013000", "DE89 3704 0044 0532 0130 00", "GB29NWBK60161331926819", "de89370400440532013000"}, []string{"DE88370400440532013000", "DE8937040044053201300", "XX89370400440532013000!", "DE"}},
+		{ValidatorISBN(), []string{"0-306-40615-2", "080442957X", "978-3-16-148410-0", "9780306406157"}, []string{"0-306-40615-3", "978-3-16-148410-1", "12345", "080442957Y"}},
+		{ValidatorSemver(), []string{"1.2.3", "0.0.0", "1.4.0-beta.1", "2.0.0+build.5"}, []string{"1.2", "v1.2.3", "01.2.3", "1.2.3-"}},
+		{ValidatorSlug(), []string{"my-post-2", "hello"}, []string{"My-Post", "my--post", "-post", "post_2"}},
+		{ValidatorJSON(), []string{`{"a":1}`, `[1,2]`, `"text"`, `null`}, []string{`{a:1}`, `[1,`, `undefined`}},
+		{ValidatorHexColor(), []string{"#ff8800", "#F80"}, []string{"ff8800", "#ff88", "#gg8800"}},
+		{ValidatorEmailAddress(), []string{"jane@example.com", `"jane doe"@example.com`, "jane+tag@localhost"}, []string{"Jane <jane@example.com>", "jane", "jane@", " jane@example.com"}},
+	}
+
+	for _, test := range tests {
+		if err := test.rule.Validate("v", "", nil); err != nil {
+			t.Errorf("%s: expected an empty value to pass, got %v", test.rule.Name(), err)
+		}
+		for _, value := range test.valid {
+			if err := test.rule.Validate("v", value, nil); err != nil {
+				t.Errorf("%s: expected %q to pass, got %v", test.rule.Name(), value, err)
+			}
+		}
+		for _, value := range test.invalid {
+			if err := test.rule.Validate("v", value, nil); err == nil {
+				t.Errorf("%s: expected %q to fail", test.rule.Name(), value)
+			}
+		}
+	}
+}
+
+func TestValidatorCreditCard(t *testing.T) {
+	rule := ValidatorCreditCard()
+	for _, value := range []string{"4111 1111 1111 1111", "5555-5555-5555-4444", "378282246310005", "6011111111111117"} {
+		if err := rule.Validate("card", value, nil); err != nil {
+			t.Errorf("Expected %q to pass, got %v", value, err)
+		}
+	}
+	for _, value := range []string{"4111 1111 1111 1112", "1234567812345670", "4111-abcd-1111-1111"} {
+		if err := rule.Validate("card", value, nil); err == nil || err.Rule != RuleCreditCard {
+			t.Errorf("Expected %q to fail, got %v", value, err)
+		}
+	}
+
+	visa := ValidatorCreditCard(CardBrandVisa, CardBrandMastercard)
+	if visa.Params()["brands"] != `["visa","mastercard"]` {
+		t.Fatal("Unexpected params:", visa.Params())
+	}
+	if err := visa.Validate("card", "4111111111111111", nil); err != nil {
+		t.Fatal("Expected a Visa card to pass, got:", err)
+	}
+
+	err := visa.Validate("Card", "378282246310005", nil)
+	if err == nil || err.Rule != RuleCreditCardBrand || err.Message != "Card must be a card of an accepted type" {
+		t.Fatal("Expected an Amex card to fail with the brand message, got:", err)
+	}
+}
+
+func TestCardBrand(t *testing.T) {
+	tests := map[string]string{
+		"4111111111111111":    CardBrandVisa,
+		"5555555555554444":    CardBrandMastercard,
+		"2221000000000009":    CardBrandMastercard,
+		"378282246310005":     CardBrandAmex,
+		"6011111111111117":    CardBrandDiscover,
+		"3530111333300000":    CardBrandJCB,
+		"30569309025904":      CardBrandDiners,
+		"6200000000000005":    CardBrandUnionPay,
+		"6759649826438453":    CardBrandMaestro,
+		"4111 1111 1111 1111": CardBrandVisa,
+		"411111111111":        "",
+		"9111111111111111":    "",
+		"":                    "",
+	}
+
+	for number, expected := range tests {
+		if brand := CardBrand(number); brand != expected {
+			t.Errorf("%q: expected %q, got %q", number, expected, brand)
+		}
+	}
+}
+
+func TestFormatValidatorMessages(t *testing.T) {
+	f := New().WithLocale("de").WithFields(
+		NewStringField("iban", "IBAN").WithValidators(ValidatorIBAN()),
+		NewStringField("email", "E-Mail").WithValidators(ValidatorEmailAddress()),
+	)
+
+	errs := f.Validate(map[string]string{"iban": "DE00", "email": "jane"})
+	if len(errs) != 2 {
+		t.Fatal("Expected 2 errors, got:", errs)
+	}
+	if errs[0].Message != "IBAN muss eine gültige IBAN sein" {
+		t.Fatal("Unexpected message:", errs[0].Message)
+	}
+	if errs[1].Rule != RuleEmail || errs[1].Message != "E-Mail muss eine gültige E-Mail-Adresse sein" {
+		t.Fatal("Expected the email message, got:", errs[1])
+	}
+}
+
+func TestFormatValidatorsDefinition(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("server", "Server").WithValidators(ValidatorIPv6(), ValidatorCIDR(), ValidatorHostname()),
+		NewStringField("phone", "Phone").WithValidators(ValidatorE164()),
+		NewStringField("card", "Card").WithValidators(ValidatorCreditCard(CardBrandVisa, CardBrandAmex)),
+		NewStringField("any_card", "Any card").WithValidators(ValidatorCreditCard()),
+		NewStringField("iban", "IBAN").WithValidators(ValidatorIBAN(), ValidatorISBN()),
+		NewStringField("version", "Version").WithValidators(ValidatorSemver(), ValidatorSlug()),
+		NewTextAreaField("data", "Data").WithValidators(ValidatorJSON()),
+		NewStringField("color", "Color").WithValidators(ValidatorHexColor()),
+		NewStringField("email", "Email").WithValidators(ValidatorEmailAddress()),
+	)
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := loaded.MarshalDefinition()
+	if err != nil || string(again) != string(data) {
+		t.Fatalf("Expected the format rules to round-trip, got:\n%s\n%v", again, err)
+	}
+
+	AssertValidationFailsOn(t, loaded, map[string]string{"card": "5555555555554444"}, "card")
+	AssertValidationPasses(t, loaded, map[string]string{"card": "4111111111111111", "any_card": "5555555555554444"})
+}
@@ -0,0 +1,112 @@
+package form
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Rule is a validation rule that can be inspected, e.g. to derive HTML5
+// constraint attributes or a JSON Schema, and run against a value.
+//
+// Name identifies the rule (e.g. "min_length") and Params holds its
+// parameters (e.g. "min": "3"), so two rules with the same name and params
+// validate the same way. List params, such as the values of ValidatorOneOf,
+// are JSON arrays, e.g. "values": `["New York, NY","Boston"]`.
+type Rule interface {
+	// Name returns the rule's name, e.g. "min_length".
+	Name() string
+
+	// Params returns the rule's parameters, e.g. "min": "3".
+	Params() map[string]string
+
+	// Validate validates the value and returns a ValidationError if it is invalid.
+	// The values are all submitted values, used by cross-field rules; may be nil.
+	Validate(fieldName string, value string, values map[string]string) *ValidationError
+}
+
+var _ Rule = Validator(nil)
+var _ Rule = (*rule)(nil)
+
+// Name returns "custom", as a func validator cannot be inspected.
+func (validator Validator) Name() string {
+	return RuleCustom
+}
+
+// Params returns nil, as a func validator cannot be inspected.
+func (validator Validator) Params() map[string]string {
+	return nil
+}
+
+// Validate calls the validator function; it does not see the values.
+func (validator Validator) Validate(fieldName string, value string, _ map[string]string) *ValidationError {
+	return validator(fieldName, value)
+}
+
+// RuleFunc adapts a func validator to a Rule with the name, so it can be
+// told apart from other func validators when a form's rules are inspected.
+// The rule has no params, and does not see the values of other fields.
+func RuleFunc(name string, fn func(fieldName string, value string) *ValidationError) Rule {
+	return newRule(name, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		return fn(fieldName, value)
+	})
+}
+
+// rule is the Rule returned by the Validator* constructors.
+type rule struct {
+	name     string
+	params   map[string]string
+	validate func(fieldName string, value string, values map[string]string) *ValidationError
+}
+
+// newRule creates a rule with the name and params, validating with the function.
+func newRule(name string, params map[string]string, validate func(fieldName string, value string, values map[string]string) *ValidationError) Rule {
+	return &rule{
+		name:     name,
+		params:   params,
+		validate: validate,
+	}
+}
+
+func (r *rule) Name() string {
+	return r.name
+}
+
+func (r *rule) Params() map[string]string {
+	if r.params == nil {
+		return nil
+	}
+	return copyParams(r.params)
+}
+
+func (r *rule) Validate(fieldName string, value string, values map[string]string) *ValidationError {
+	return r.validate(fieldName, value, values)
+}
+
+// GetRules returns the field's validation rules, in the order they run.
+func (field *Field) GetRules() []Rule {
+	return field.Validators
+}
+
+// listParam encodes a list param, such as the values of ValidatorOneOf, as a
+// JSON array, so values holding commas survive a round trip.
+func listParam(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	data, _ := json.Marshal(values)
+	return string(data)
+}
+
+// parseListParam decodes a list param encoded by listParam. An empty param
+// is an empty list.
+func parseListParam(param string) ([]string, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	values := []string{}
+	if err := json.Unmarshal([]byte(param), &values); err != nil {
+		return nil, errors.New("invalid list " + param + ", expected a JSON array of strings")
+	}
+	return values, nil
+}
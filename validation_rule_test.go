@@ -0,0 +1,194 @@
+package form
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFieldGetRules(t *testing.T) {
+	field := NewStringField("code", "Code").WithValidators(
+		ValidatorRequired(),
+		ValidatorMinLength(3),
+		ValidatorMaxLength(10),
+		ValidatorPattern(`^[A-Z]+$`, "must be uppercase"),
+		ValidatorOneOf("ABC", "XYZ"),
+		ValidatorSameAs("other"),
+		ValidatorRequiredIf("country", "de", "at"),
+	)
+
+	expected := []struct {
+		name   string
+		params map[string]string
+	}{
+		{RuleRequired, nil},
+		{RuleMinLength, map[string]string{"min": "3"}},
+		{RuleMaxLength, map[string]string{"max": "10"}},
+		{RulePattern, map[string]string{"pattern": `^[A-Z]+$`, "message": "must be uppercase"}},
+		{RuleOneOf, map[string]string{"values": `["ABC","XYZ"]`}},
+		{RuleSameAs, map[string]string{"other": "other"}},
+		{RuleRequiredIf, map[string]string{"other": "country", "values": `["de","at"]`}},
+	}
+
+	rules := field.GetRules()
+	if len(rules) != len(expected) {
+		t.Fatal("Expected", len(expected), "rules, got:", len(rules))
+	}
+
+	for i, rule := range rules {
+		if rule.Name() != expected[i].name {
+			t.Fatal("Expected rule", expected[i].name, "got:", rule.Name())
+		}
+		if !reflect.DeepEqual(rule.Params(), expected[i].params) {
+			t.Fatal("Unexpected params of", rule.Name(), ":", rule.Params())
+		}
+	}
+}
+
+func TestRuleNames(t *testing.T) {
+	rules := map[string]Rule{
+		RuleMin:              ValidatorMin(1),
+		RuleMax:              ValidatorMax(2),
+		RuleEmail:            ValidatorEmail(),
+		RuleURL:              ValidatorURL(),
+		RuleIP:               ValidatorIP(),
+		RuleUUID:             ValidatorUUID(),
+		RuleAlphaNumeric:     ValidatorAlphaNumeric(),
+		RuleGreaterThanField: ValidatorGreaterThanField("start"),
+		RuleRequiredUnless:   ValidatorRequiredUnless("other"),
+		RuleCrossField:       ValidatorCrossField(func(string, string, map[string]string) *ValidationError { return nil }),
+		RuleCustom:           ValidatorCustom(func(string) string { return "" }),
+	}
+
+	for name, rule := range rules {
+		if rule.Name() != name {
+			t.Fatal("Expected rule", name, "got:", rule.Name())
+		}
+	}
+}
+
+func TestRuleParamsAreCopies(t *testing.T) {
+	rule := ValidatorMinLength(3)
+	rule.Params()["min"] = "100"
+
+	if rule.Params()["min"] != "3" {
+		t.Fatal("Expected params to be unchanged, got:", rule.Params())
+	}
+	if err := rule.Validate("code", "abcd", nil); err != nil {
+		t.Fatal("Expected valid value, got:", err.Message)
+	}
+}
+
+func TestRulesCompare(t *testing.T) {
+	a := ValidatorMax(10)
+	b := ValidatorMax(10)
+	c := ValidatorMax(20)
+
+	if a.Name() != b.Name() || !reflect.DeepEqual(a.Params(), b.Params()) {
+		t.Fatal("Expected equal rules")
+	}
+	if reflect.DeepEqual(a.Params(), c.Params()) {
+		t.Fatal("Expected different rules")
+	}
+}
+
+func TestRuleFunc(t *testing.T) {
+	reserved := func(fieldName string, value string) *ValidationError {
+		if value == "admin" {
+			return &ValidationError{Field: fieldName, Message: "is reserved"}
+		}
+		return nil
+	}
+
+	r := RuleFunc("reserved", reserved)
+	if r.Name() != "reserved" || r.Params() != nil {
+		t.Fatal("Expected a named rule without params, got:", r.Name(), r.Params())
+	}
+
+	f := New().WithFields(NewStringField("username", "Username").WithValidators(r, Validator(reserved)))
+	errs := f.Validate(map[string]string{"username": "admin"})
+	if len(errs) != 2 || errs[0].Message != "is reserved" || errs[1].Message != "is reserved" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+	if err := r.Validate("username", "jane", nil); err != nil {
+		t.Fatal("Expected valid value, got:", err.Message)
+	}
+}
+
+func TestValidatorFuncAdapter(t *testing.T) {
+	fn := Validator(func(fieldName string, value string) *ValidationError {
+		if value != "ok" {
+			return &ValidationError{Field: fieldName, Message: "not ok"}
+		}
+		return nil
+	})
+
+	if fn.Name() != RuleCustom || fn.Params() != nil {
+		t.Fatal("Expected an opaque custom rule, got:", fn.Name(), fn.Params())
+	}
+
+	f := New().WithFields(NewStringField("status", "Status").WithValidators(fn))
+
+	errs := f.Validate(map[string]string{"status": "bad", "other": "1"})
+	if len(errs) != 1 || errs[0].Message != "not ok" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+
+	if err := fn.Validate("status", "ok", nil); err != nil {
+		t.Fatal("Expected valid value, got:", err.Message)
+	}
+}
+
+func TestListParamsRoundTrip(t *testing.T) {
+	f := New().WithFields(
+		NewStringField("city", "City").WithValidators(ValidatorOneOf("New York, NY", "Boston")),
+		NewStringField("card", "Card").WithValidators(ValidatorCreditCard(CardBrandVisa, CardBrandAmex)),
+		NewStringField("contact", "Contact"),
+		NewStringField("phone", "Phone").WithValidators(ValidatorRequiredIf("contact", "phone, sms", "call")),
+	)
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rule := range []string{"city", "card", "phone"} {
+		original := f.findField(rule).(*Field).Validators[0].Params()
+		restored := loaded.findField(rule).(*Field).Validators[0].Params()
+		if !reflect.DeepEqual(original, restored) {
+			t.Errorf("%s: expected params %v, got %v", rule, original, restored)
+		}
+	}
+
+	errs := loaded.Validate(map[string]string{"city": "New York, NY", "contact": "phone, sms", "phone": ""})
+	if len(errs) != 1 || errs[0].Field != "phone" {
+		t.Fatal("Expected only the required phone to fail, got:", errs)
+	}
+
+	schema, err := f.JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(schema), `"enum":["New York, NY","Boston"]`) {
+		t.Fatal("Expected the values as the enum, got:", string(schema))
+	}
+
+	imported, err := FromJSONSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := imported.Validate(map[string]string{"city": "New York, NY"}); len(errs) != 0 {
+		t.Fatal("Expected the imported enum to accept the value, got:", errs)
+	}
+}
+
+func TestListParamsInvalid(t *testing.T) {
+	definition := `{"version": 1, "fields": [{"type": "string", "name": "a", "rules": [{"name": "one_of", "params": {"values": "a, b"}}]}]}`
+	if _, err := LoadDefinition([]byte(definition)); err == nil || !strings.Contains(err.Error(), "expected a JSON array of strings") {
+		t.Fatal("Expected an error for a list that is not a JSON array, got:", err)
+	}
+}
@@ -6,117 +6,117 @@ import (
 
 func TestValidatorURL_Valid(t *testing.T) {
 	v := ValidatorURL()
-	if err := v("url", "https://example.com"); err != nil {
+	if err := v.Validate("url", "https://example.com", nil); err != nil {
 		t.Fatal("Expected valid URL, got error:", err.Message)
 	}
-	if err := v("url", "http://example.com/path?q=1"); err != nil {
+	if err := v.Validate("url", "http://example.com/path?q=1", nil); err != nil {
 		t.Fatal("Expected valid URL, got error:", err.Message)
 	}
 }
 
 func TestValidatorURL_Invalid(t *testing.T) {
 	v := ValidatorURL()
-	if err := v("url", "not-a-url"); err == nil {
+	if err := v.Validate("url", "not-a-url", nil); err == nil {
 		t.Fatal("Expected error for invalid URL")
 	}
-	if err := v("url", "ftp://example.com"); err == nil {
+	if err := v.Validate("url", "ftp://example.com", nil); err == nil {
 		t.Fatal("Expected error for ftp URL")
 	}
 }
 
 func TestValidatorURL_Empty(t *testing.T) {
 	v := ValidatorURL()
-	if err := v("url", ""); err != nil {
+	if err := v.Validate("url", "", nil); err != nil {
 		t.Fatal("Expected no error for empty value, got:", err.Message)
 	}
 }
 
 func TestValidatorIP_Valid(t *testing.T) {
 	v := ValidatorIP()
-	if err := v("ip", "192.168.1.1"); err != nil {
+	if err := v.Validate("ip", "192.168.1.1", nil); err != nil {
 		t.Fatal("Expected valid IP, got error:", err.Message)
 	}
-	if err := v("ip", "0.0.0.0"); err != nil {
+	if err := v.Validate("ip", "0.0.0.0", nil); err != nil {
 		t.Fatal("Expected valid IP, got error:", err.Message)
 	}
-	if err := v("ip", "255.255.255.255"); err != nil {
+	if err := v.Validate("ip", "255.255.255.255", nil); err != nil {
 		t.Fatal("Expected valid IP, got error:", err.Message)
 	}
 }
 
 func TestValidatorIP_Invalid(t *testing.T) {
 	v := ValidatorIP()
-	if err := v("ip", "999.999.999.999"); err == nil {
+	if err := v.Validate("ip", "999.999.999.999", nil); err == nil {
 		t.Fatal("Expected error for invalid IP")
 	}
-	if err := v("ip", "abc"); err == nil {
+	if err := v.Validate("ip", "abc", nil); err == nil {
 		t.Fatal("Expected error for non-IP string")
 	}
 }
 
 func TestValidatorUUID_Valid(t *testing.T) {
 	v := ValidatorUUID()
-	if err := v("id", "550e8400-e29b-41d4-a716-446655440000"); err != nil {
+	if err := v.Validate("id", "550e8400-e29b-41d4-a716-446655440000", nil); err != nil {
 		t.Fatal("Expected valid UUID, got error:", err.Message)
 	}
-	if err := v("id", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"); err != nil {
+	if err := v.Validate("id", "6ba7b810-9dad-11d1-80b4-00c04fd430c8", nil); err != nil {
 		t.Fatal("Expected valid UUID, got error:", err.Message)
 	}
 }
 
 func TestValidatorUUID_Invalid(t *testing.T) {
 	v := ValidatorUUID()
-	if err := v("id", "not-a-uuid"); err == nil {
+	if err := v.Validate("id", "not-a-uuid", nil); err == nil {
 		t.Fatal("Expected error for invalid UUID")
 	}
-	if err := v("id", "550e8400e29b41d4a716446655440000"); err == nil {
+	if err := v.Validate("id", "550e8400e29b41d4a716446655440000", nil); err == nil {
 		t.Fatal("Expected error for UUID without dashes")
 	}
 }
 
 func TestValidatorAlphaNumeric_Valid(t *testing.T) {
 	v := ValidatorAlphaNumeric()
-	if err := v("code", "abc123"); err != nil {
+	if err := v.Validate("code", "abc123", nil); err != nil {
 		t.Fatal("Expected valid alphanumeric, got error:", err.Message)
 	}
-	if err := v("code", "ABC"); err != nil {
+	if err := v.Validate("code", "ABC", nil); err != nil {
 		t.Fatal("Expected valid alphanumeric, got error:", err.Message)
 	}
 }
 
 func TestValidatorAlphaNumeric_Invalid(t *testing.T) {
 	v := ValidatorAlphaNumeric()
-	if err := v("code", "abc-123"); err == nil {
+	if err := v.Validate("code", "abc-123", nil); err == nil {
 		t.Fatal("Expected error for string with dash")
 	}
-	if err := v("code", "hello world"); err == nil {
+	if err := v.Validate("code", "hello world", nil); err == nil {
 		t.Fatal("Expected error for string with space")
 	}
-	if err := v("code", "test@email"); err == nil {
+	if err := v.Validate("code", "test@email", nil); err == nil {
 		t.Fatal("Expected error for string with @")
 	}
 }
 
 func TestValidatorOneOf_Valid(t *testing.T) {
 	v := ValidatorOneOf("red", "green", "blue")
-	if err := v("color", "red"); err != nil {
+	if err := v.Validate("color", "red", nil); err != nil {
 		t.Fatal("Expected valid value, got error:", err.Message)
 	}
-	if err := v("color", "blue"); err != nil {
+	if err := v.Validate("color", "blue", nil); err != nil {
 		t.Fatal("Expected valid value, got error:", err.Message)
 	}
 }
 
 func TestValidatorOneOf_Invalid(t *testing.T) {
 	v := ValidatorOneOf("red", "green", "blue")
-	if err := v("color", "yellow"); err == nil {
+	if err := v.Validate("color", "yellow", nil); err == nil {
 		t.Fatal("Expected error for value not in list")
 	}
 }
 
 func TestValidatorOneOf_Empty(t *testing.T) {
 	v := ValidatorOneOf("red", "green", "blue")
-	if err := v("color", ""); err != nil {
+	if err := v.Validate("color", "", nil); err != nil {
 		t.Fatal("Expected no error for empty value, got:", err.Message)
 	}
 }
@@ -128,7 +128,7 @@ func TestValidatorCustom_Valid(t *testing.T) {
 		}
 		return "must be 'secret'"
 	})
-	if err := v("code", "secret"); err != nil {
+	if err := v.Validate("code", "secret", nil); err != nil {
 		t.Fatal("Expected valid, got error:", err.Message)
 	}
 }
@@ -140,7 +140,7 @@ func TestValidatorCustom_Invalid(t *testing.T) {
 		}
 		return "must be 'secret'"
 	})
-	err := v("code", "wrong")
+	err := v.Validate("code", "wrong", nil)
 	if err == nil {
 		t.Fatal("Expected error for invalid value")
 	}
@@ -156,7 +156,7 @@ func TestValidatorCustom_WithFieldName(t *testing.T) {
 		}
 		return "must start with #"
 	})
-	err := v("color", "red")
+	err := v.Validate("color", "red", nil)
 	if err == nil {
 		t.Fatal("Expected error")
 	}
@@ -1,6 +1,8 @@
 package form
 
 import (
+	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -54,7 +56,7 @@ func TestValidateMinLength(t *testing.T) {
 			&Field{
 				Name:       "password",
 				Type:       FORM_FIELD_TYPE_PASSWORD,
-				Validators: []Validator{ValidatorMinLength(8)},
+				Validators: []Rule{ValidatorMinLength(8)},
 			},
 		},
 	})
@@ -74,7 +76,7 @@ func TestValidateMaxLength(t *testing.T) {
 			&Field{
 				Name:       "username",
 				Type:       FORM_FIELD_TYPE_STRING,
-				Validators: []Validator{ValidatorMaxLength(5)},
+				Validators: []Rule{ValidatorMaxLength(5)},
 			},
 		},
 	})
@@ -94,7 +96,7 @@ func TestValidateMin(t *testing.T) {
 			&Field{
 				Name:       "age",
 				Type:       FORM_FIELD_TYPE_NUMBER,
-				Validators: []Validator{ValidatorMin(18)},
+				Validators: []Rule{ValidatorMin(18)},
 			},
 		},
 	})
@@ -114,7 +116,7 @@ func TestValidateMax(t *testing.T) {
 			&Field{
 				Name:       "quantity",
 				Type:       FORM_FIELD_TYPE_NUMBER,
-				Validators: []Validator{ValidatorMax(100)},
+				Validators: []Rule{ValidatorMax(100)},
 			},
 		},
 	})
@@ -134,7 +136,7 @@ func TestValidatePattern(t *testing.T) {
 			&Field{
 				Name:       "code",
 				Type:       FORM_FIELD_TYPE_STRING,
-				Validators: []Validator{ValidatorPattern(`^[A-Z]{3}$`, "must be 3 uppercase letters")},
+				Validators: []Rule{ValidatorPattern(`^[A-Z]{3}$`, "must be 3 uppercase letters")},
 			},
 		},
 	})
@@ -162,7 +164,7 @@ func TestValidateEmail(t *testing.T) {
 			&Field{
 				Name:       "email",
 				Type:       FORM_FIELD_TYPE_EMAIL,
-				Validators: []Validator{ValidatorEmail()},
+				Validators: []Rule{ValidatorEmail()},
 			},
 		},
 	})
@@ -191,7 +193,7 @@ func TestValidateMultipleValidators(t *testing.T) {
 				Name:     "password",
 				Type:     FORM_FIELD_TYPE_PASSWORD,
 				Required: true,
-				Validators: []Validator{
+				Validators: []Rule{
 					ValidatorMinLength(8),
 					ValidatorMaxLength(64),
 				},
@@ -227,6 +229,28 @@ func TestValidateMultipleValidators(t *testing.T) {
 	}
 }
 
+func TestValidateMultipleSelect(t *testing.T) {
+	sizes := NewSelectField("sizes", "Sizes", []FieldOption{{Key: "s", Value: "S"}, {Key: "m, l", Value: "M or L"}}).
+		WithMultiple().
+		WithRequired().
+		WithValidators(ValidatorOneOf("s", "m, l"))
+	f := New().WithFields(sizes)
+
+	if errs := f.Validate(f.ParseValues(url.Values{"sizes": {"s", "m, l"}})); len(errs) != 0 {
+		t.Fatal("Expected each selected value to pass, got:", errs)
+	}
+
+	errs := f.Validate(f.ParseValues(url.Values{"sizes": {"s", "xl"}}))
+	if len(errs) != 1 || errs[0].Rule != RuleOneOf || errs[0].Field != "sizes" {
+		t.Fatal("Expected a one of error for the unknown value, got:", errs)
+	}
+
+	errs = f.Validate(f.ParseValues(url.Values{}))
+	if len(errs) != 1 || errs[0].Rule != RuleRequired {
+		t.Fatal("Expected a required error without a selection, got:", errs)
+	}
+}
+
 func TestValidateNoFields(t *testing.T) {
 	form := NewForm(FormOptions{})
 
@@ -238,3 +262,64 @@ func TestValidateNoFields(t *testing.T) {
 		t.Fatal("Expected 0 errors for empty form, got:", len(errors))
 	}
 }
+
+func TestValidateFieldRowChildren(t *testing.T) {
+	form := New().WithFields(
+		NewFieldRowWithColumns(
+			FieldRowColumn{Field: NewStringField("city", "City").WithRequired()},
+			FieldRowColumn{Field: NewFieldRow(
+				NewStringField("zip", "ZIP").WithValidators(ValidatorMinLength(4)),
+			)},
+		),
+	)
+
+	errors := form.Validate(map[string]string{"zip": "12"})
+
+	if len(errors) != 2 {
+		t.Fatal("Expected 2 errors, got:", errors)
+	}
+	if errors[0].Field != "city" || errors[1].Field != "zip" {
+		t.Fatal("Unexpected error fields:", errors)
+	}
+}
+
+func TestValidateRepeaterItems(t *testing.T) {
+	repeater := NewRepeater(RepeaterOptions{
+		Name: "contacts",
+		Fields: []FieldInterface{
+			NewStringField("name", "Name").WithRequired(),
+			NewEmailField("email", "Email").WithValidators(ValidatorEmail()),
+		},
+		RepeaterAddUrl:    "/add",
+		RepeaterRemoveUrl: "/remove",
+	})
+	form := New().WithFields(repeater)
+
+	values := form.ParseValues(url.Values{
+		"contacts[name][0]":  {"Ann"},
+		"contacts[email][0]": {"ann@example.com"},
+		"contacts[name][1]":  {""},
+		"contacts[email][1]": {"bob@example.com"},
+		"contacts[name][2]":  {"Cid"},
+		"contacts[email][2]": {"not-an-email"},
+	})
+
+	errors := form.Validate(values)
+
+	if len(errors) != 2 {
+		t.Fatal("Expected 2 errors, got:", errors)
+	}
+	if errors[0].Field != "contacts[name][1]" || errors[1].Field != "contacts[email][2]" {
+		t.Fatal("Unexpected error fields:", errors)
+	}
+
+	html := form.Build().ToHTML()
+
+	expected := `name="contacts[email][2]" type="email" value="not-an-email" /><div class="invalid-feedback">`
+	if !strings.Contains(html, expected) {
+		t.Fatal("Expected error on the third item, got:", html)
+	}
+	if strings.Count(html, `class="invalid-feedback"`) != 2 {
+		t.Fatal("Expected exactly 2 inline errors, got:", html)
+	}
+}
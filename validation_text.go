@@ -0,0 +1,231 @@
+package form
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ValidatorMinGraphemes returns a validator that checks if a value has at
+// least minLength user-perceived characters, so that an emoji built of
+// several code points or a letter with a combining accent counts once.
+// It fails with the "min_length" message.
+func ValidatorMinGraphemes(minLength int) Rule {
+	params := map[string]string{"min": strconv.Itoa(minLength)}
+	return newRule(RuleMinGraphemes, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if graphemeCount(value) < minLength {
+			return ruleError(fieldName, RuleMinLength, params)
+		}
+		return nil
+	})
+}
+
+// ValidatorMaxGraphemes returns a validator that checks if a value has at
+// most maxLength user-perceived characters. It fails with the "max_length"
+// message.
+func ValidatorMaxGraphemes(maxLength int) Rule {
+	params := map[string]string{"max": strconv.Itoa(maxLength)}
+	return newRule(RuleMaxGraphemes, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if graphemeCount(value) > maxLength {
+			return ruleError(fieldName, RuleMaxLength, params)
+		}
+		return nil
+	})
+}
+
+// ValidatorMaxBytes returns a validator that checks if a value is at most
+// maxBytes bytes long in UTF-8, e.g. the size of a VARCHAR column counted
+// in bytes.
+func ValidatorMaxBytes(maxBytes int) Rule {
+	params := map[string]string{"max": strconv.Itoa(maxBytes)}
+	return newRule(RuleMaxBytes, params, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		if len(value) > maxBytes {
+			return ruleError(fieldName, RuleMaxBytes, params)
+		}
+		return nil
+	})
+}
+
+// ValidatorNoConfusables returns a validator for user names and other
+// identifiers that rejects text which can pass for different text:
+// invisible characters, such as zero-width spaces and bidi controls, fail
+// with the "invisible" message; letters of scripts that are not commonly
+// written together, such as Latin and Cyrillic, and compatibility
+// characters that look like ASCII, such as fullwidth or mathematical
+// letters, fail with the "confusable" message.
+//
+// Latin may be mixed with Han and Japanese kana, Han and Bopomofo, or Han
+// and Hangul, following the highly restrictive level of Unicode TS #39.
+func ValidatorNoConfusables() Rule {
+	return newRule(RuleConfusable, nil, func(fieldName string, value string, _ map[string]string) *ValidationError {
+		for _, r := range value {
+			if isInvisible(r) {
+				return ruleError(fieldName, RuleInvisible, nil)
+			}
+		}
+
+		if isMixedScript(value) || hasASCIILookalike(value) {
+			return ruleError(fieldName, RuleConfusable, nil)
+		}
+		return nil
+	})
+}
+
+// isInvisible reports whether r is a control or format character, or a
+// default ignorable code point, such as the Hangul filler, that renders
+// as nothing.
+func isInvisible(r rune) bool {
+	return unicode.Is(unicode.Cc, r) ||
+		unicode.Is(unicode.Cf, r) ||
+		unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r) ||
+		unicode.Is(unicode.Variation_Selector, r)
+}
+
+// scriptSets are the scripts that may be mixed, besides a single script.
+var scriptSets = []map[string]bool{
+	{"Latin": true, "Han": true, "Hiragana": true, "Katakana": true},
+	{"Latin": true, "Han": true, "Bopomofo": true},
+	{"Latin": true, "Han": true, "Hangul": true},
+}
+
+// isMixedScript reports whether the value has letters of scripts that are
+// not in one of the scriptSets. Common characters, such as digits and
+// punctuation, and inherited ones, such as combining marks, have no script.
+func isMixedScript(value string) bool {
+	scripts := map[string]bool{}
+	for _, r := range value {
+		if script := runeScript(r); script != "" {
+			scripts[script] = true
+		}
+	}
+
+	if len(scripts) <= 1 {
+		return false
+	}
+
+	for _, set := range scriptSets {
+		allowed := true
+		for script := range scripts {
+			if !set[script] {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// runeScript returns the name of the script of r, or "" for common and
+// inherited characters.
+func runeScript(r rune) string {
+	if r < utf8.RuneSelf {
+		if unicode.IsLetter(r) {
+			return "Latin"
+		}
+		return ""
+	}
+
+	for name, table := range unicode.Scripts {
+		if name != "Common" && name != "Inherited" && unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasASCIILookalike reports whether the value has a non-ASCII character
+// whose compatibility decomposition is ASCII, e.g. "ａ" (fullwidth) or "𝐚"
+// (mathematical bold), both "a".
+func hasASCIILookalike(value string) bool {
+	for _, r := range value {
+		if r < utf8.RuneSelf {
+			continue
+		}
+
+		decomposed := norm.NFKC.String(string(r))
+		ascii := true
+		for _, d := range decomposed {
+			if d >= utf8.RuneSelf {
+				ascii = false
+				break
+			}
+		}
+		if ascii && decomposed != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// graphemeCount returns the number of user-perceived characters of the
+// value. It approximates the extended grapheme clusters of Unicode
+// UAX #29: marks, joiners, variation selectors, emoji modifiers and tags
+// extend the preceding character, a zero width joiner joins the next one,
+// regional indicators pair into flags, Hangul jamo join into syllables
+// and CR LF counts once.
+func graphemeCount(value string) int {
+	count := 0
+	var previous rune = -1
+	regionalIndicators := 0
+
+	for _, r := range value {
+		joins := previous >= 0 && !unicode.Is(unicode.Cc, previous) && !unicode.Is(unicode.Cc, r) &&
+			(extendsGrapheme(r) || previous == zeroWidthJoiner || joinsHangul(previous, r))
+
+		if previous == '\r' && r == '\n' {
+			joins = true
+		}
+
+		if unicode.Is(unicode.Regional_Indicator, r) {
+			if unicode.Is(unicode.Regional_Indicator, previous) && regionalIndicators%2 == 1 {
+				joins = true
+			}
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+
+		if !joins {
+			count++
+		}
+		previous = r
+	}
+
+	return count
+}
+
+// zeroWidthJoiner joins emoji into sequences, e.g. a family.
+const zeroWidthJoiner = '\u200d'
+
+// extendsGrapheme reports whether r extends the preceding character.
+func extendsGrapheme(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector) ||
+		r == zeroWidthJoiner ||
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji modifiers
+		(r >= 0xe0020 && r <= 0xe007f) // tags
+}
+
+// joinsHangul reports whether the Hangul jamo r continues the syllable
+// ending with previous, the way conjoining jamo compose into syllables:
+// leading consonants, then vowels, then trailing consonants.
+func joinsHangul(previous rune, r rune) bool {
+	leading := func(r rune) bool { return (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c) }
+	vowel := func(r rune) bool { return (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6) }
+	trailing := func(r rune) bool { return (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb) }
+	syllable := func(r rune) bool { return r >= 0xac00 && r <= 0xd7a3 }
+
+	switch {
+	case leading(previous):
+		return leading(r) || vowel(r) || syllable(r)
+	case vowel(previous), syllable(previous):
+		return vowel(r) || trailing(r)
+	case trailing(previous):
+		return trailing(r)
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package form
+
+import (
+	"testing"
+)
+
+func TestLengthValidatorsCountCharacters(t *testing.T) {
+	if err := ValidatorMaxLength(3).Validate("name", "Zoë", nil); err != nil {
+		t.Fatal("Expected Zoë to be 3 characters, got:", err)
+	}
+	if err := ValidatorMaxLength(5).Validate("name", "こんにちは", nil); err != nil {
+		t.Fatal("Expected 5 Japanese characters to pass, got:", err)
+	}
+	if err := ValidatorMinLength(4).Validate("name", "Zoë", nil); err == nil {
+		t.Fatal("Expected Zoë to be shorter than 4 characters")
+	}
+}
+
+func TestGraphemeCount(t *testing.T) {
+	tests := map[string]int{
+		"":                     0,
+		"abc":                  3,
+		"Zoë":                  3,
+		"Zoe\u0308":            3,
+		"こんにちは":                5,
+		"\U0001F44D\U0001F3FD": 1, // thumbs up, medium skin tone
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467": 1, // family
+		"\U0001F1E9\U0001F1EA\U0001F1EB\U0001F1F7":   2, // flags DE and FR
+		"\U0001F1E9":                           1,
+		"\u2764\ufe0f":                         1, // red heart
+		"\u1112\u1161\u11ab\u1100\u1173\u11af": 2, // 한글 as conjoining jamo
+		"한글":                                   2,
+		"a\r\nb":                               3,
+		"\u0301a":                              2,
+	}
+
+	for value, expected := range tests {
+		if count := graphemeCount(value); count != expected {
+			t.Errorf("%q: expected %d, got %d", value, expected, count)
+		}
+	}
+}
+
+func TestValidatorGraphemes(t *testing.T) {
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+
+	if err := ValidatorMaxGraphemes(2).Validate("bio", family+"!", nil); err != nil {
+		t.Fatal("Expected 2 graphemes to pass, got:", err)
+	}
+
+	err := ValidatorMaxGraphemes(1).Validate("Bio", family+"!", nil)
+	if err == nil || err.Rule != RuleMaxLength || err.Message != "Bio must be at most 1 characters" {
+		t.Fatal("Expected the max length message, got:", err)
+	}
+
+	err = ValidatorMinGraphemes(2).Validate("Bio", family, nil)
+	if err == nil || err.Rule != RuleMinLength {
+		t.Fatal("Expected the min length message, got:", err)
+	}
+}
+
+func TestValidatorMaxBytes(t *testing.T) {
+	rule := ValidatorMaxBytes(4)
+	if err := rule.Validate("code", "Zoë", nil); err != nil {
+		t.Fatal("Expected 4 bytes to pass, got:", err)
+	}
+
+	err := rule.Validate("Code", "Zoës", nil)
+	if err == nil || err.Rule != RuleMaxBytes || err.Message != "Code is too long" {
+		t.Fatal("Expected 5 bytes to fail, got:", err)
+	}
+}
+
+func TestValidatorNoConfusables(t *testing.T) {
+	tests := []struct {
+		value string
+		rule  string
+	}{
+		{"jane_doe", ""},
+		{"Zoë", ""},
+		{"Zoe\u0308", ""},
+		{"иван", ""},
+		{"山田taro", ""},
+		{"やまだ太郎2024", ""},
+		{"김민준kim", ""},
+		{"", ""},
+		{"p\u0430ypal", RuleConfusable},    // Cyrillic a
+		{"\u03bfpenai", RuleConfusable},    // Greek omicron
+		{"\uff41dmin", RuleConfusable},     // fullwidth a
+		{"\U0001D41Admin", RuleConfusable}, // mathematical bold a
+		{"ad\u200bmin", RuleInvisible},     // zero width space
+		{"admin\u202e", RuleInvisible},     // right-to-left override
+		{"ad\u3164min", RuleInvisible},     // Hangul filler
+		{"admin\ufe0f", RuleInvisible},     // variation selector
+		{"ad\tmin", RuleInvisible},
+	}
+
+	rule := ValidatorNoConfusables()
+	for _, test := range tests {
+		err := rule.Validate("username", test.value, nil)
+		switch {
+		case test.rule == "" && err != nil:
+			t.Errorf("%q: expected to pass, got %v", test.value, err)
+		case test.rule != "" && (err == nil || err.Rule != test.rule):
+			t.Errorf("%q: expected a %s error, got %v", test.value, test.rule, err)
+		}
+	}
+}
+
+func TestTextValidatorsDefinition(t *testing.T) {
+	f := New().WithLocale("es").WithFields(
+		NewStringField("username", "Usuario").WithValidators(
+			ValidatorMinGraphemes(3),
+			ValidatorMaxGraphemes(20),
+			ValidatorMaxBytes(64),
+			ValidatorNoConfusables(),
+		),
+	)
+
+	data, err := f.MarshalDefinition()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDefinition(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := loaded.MarshalDefinition()
+	if err != nil || string(again) != string(data) {
+		t.Fatalf("Expected the text rules to round-trip, got:\n%s\n%v", again, err)
+	}
+
+	errs := loaded.Validate(map[string]string{"username": "ad\u200bmin"})
+	if len(errs) != 1 || errs[0].Message != "Usuario no debe contener caracteres invisibles" {
+		t.Fatal("Unexpected errors:", errs)
+	}
+}